@@ -0,0 +1,43 @@
+package rd_station
+
+import "context"
+
+// Activity is a note registered on a deal, e.g. the summary of a call
+type Activity struct {
+	ID        string `json:"_id"`
+	DealID    string `json:"deal_id"`
+	UserID    string `json:"user_id"`
+	Text      string `json:"text"`
+	Date      string `json:"date"`
+	CreatedAt string `json:"created_at"`
+	User      *User  `json:"user"`
+}
+
+type ListActivitiesFilterRequest struct {
+	// DealID lists the activities of the deal
+	DealID string `form:"deal_id,omitempty" query:"deal_id"`
+	Page   string `form:"page,omitempty" query:"page"`
+	Limit  string `form:"limit,omitempty" query:"limit"`
+}
+
+type ListActivitiesResponse struct {
+	Activities []Activity `json:"activities"`
+	HasMore    bool       `json:"has_more"`
+	Total      int        `json:"total"`
+}
+
+func (r ListActivitiesFilterRequest) Validate() error {
+	var v validator
+	v.page(r.Page, r.Limit)
+
+	return v.err()
+}
+
+func (s *activitiesService) List(ctx context.Context, filter ListActivitiesFilterRequest, opts ...CallOption) (*ListActivitiesResponse, error) {
+	return do[ListActivitiesResponse](withCallOptions(ctx, opts), s.client, listActivitiesEndpoint, filter)
+}
+
+// ListActivities lists the activities, usually of a deal, see ListActivitiesFilterRequest.DealID
+func (s *Client) ListActivities(ctx context.Context, filter ListActivitiesFilterRequest, opts ...CallOption) (*ListActivitiesResponse, error) {
+	return s.Activities.List(ctx, filter, opts...)
+}
@@ -0,0 +1,31 @@
+package rd_station_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rd_station "github.com/verbeux-ai/rd-station-go"
+)
+
+func TestListActivities(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/activities", r.URL.Path)
+		assert.Equal(t, "d1", r.URL.Query().Get("deal_id"))
+		_, _ = w.Write([]byte(`{"activities":[{"_id":"a1","deal_id":"d1","text":"called Maria"}],"has_more":false,"total":1}`))
+	}))
+	defer server.Close()
+
+	client := rd_station.NewClient(rd_station.WithBaseUrl(server.URL))
+
+	activities, err := client.ListActivities(context.Background(), rd_station.ListActivitiesFilterRequest{DealID: "d1"})
+	require.NoError(t, err)
+	require.Len(t, activities.Activities, 1)
+	assert.Equal(t, "called Maria", activities.Activities[0].Text)
+
+	_, err = client.ListActivities(context.Background(), rd_station.ListActivitiesFilterRequest{Limit: "500"})
+	assert.ErrorIs(t, err, rd_station.ErrValidation)
+}
@@ -0,0 +1,108 @@
+// Package analytics computes pipeline reports from deals fetched with rd_station.Client.ListDealsFilter.
+package analytics
+
+import (
+	"time"
+
+	rd_station "github.com/verbeux-ai/rd-station-go"
+)
+
+const (
+	dealWon  = "true"
+	dealLost = "false"
+)
+
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.000-0700",
+	"2006-01-02T15:04:05",
+	time.DateOnly,
+}
+
+type config struct {
+	now      func() time.Time
+	location *time.Location
+}
+
+// Option is a function that configures the report computation
+type Option func(*config)
+
+// WithNow sets the reference time used for stages that haven't ended yet
+func WithNow(now func() time.Time) Option {
+	return func(c *config) {
+		c.now = now
+	}
+}
+
+// WithLocation sets the location used to bucket prediction dates into months
+func WithLocation(location *time.Location) Option {
+	return func(c *config) {
+		c.location = location
+	}
+}
+
+func newConfig(opts []Option) config {
+	c := config{
+		now:      time.Now,
+		location: time.UTC,
+	}
+
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return c
+}
+
+// Report aggregates every analysis for a set of deals
+type Report struct {
+	GeneratedAt       time.Time         `json:"generated_at"`
+	Deals             int               `json:"deals"`
+	TimeInStage       []StageDuration   `json:"time_in_stage"`
+	StageConversions  []StageConversion `json:"stage_conversions"`
+	WinRateByPipeline []WinRate         `json:"win_rate_by_pipeline"`
+	WinRateByUser     []WinRate         `json:"win_rate_by_user"`
+	WinRateBySource   []WinRate         `json:"win_rate_by_source"`
+	Cycle             CycleLength       `json:"cycle"`
+	Forecast          []ForecastMonth   `json:"forecast"`
+}
+
+// BuildReport computes every analysis for the given deals
+func BuildReport(deals []rd_station.Deal, opts ...Option) Report {
+	c := newConfig(opts)
+
+	return Report{
+		GeneratedAt:       c.now(),
+		Deals:             len(deals),
+		TimeInStage:       TimeInStage(deals, opts...),
+		StageConversions:  StageConversions(deals),
+		WinRateByPipeline: WinRates(deals, ByPipeline),
+		WinRateByUser:     WinRates(deals, ByUser),
+		WinRateBySource:   WinRates(deals, BySource),
+		Cycle:             AverageCycle(deals),
+		Forecast:          Forecast(deals, opts...),
+	}
+}
+
+// parseTime parses the API dates, interpreting the ones without offset (like prediction dates) in location
+func parseTime(value string, location *time.Location) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, value, location); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func ratio(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+
+	return float64(part) / float64(total)
+}
@@ -0,0 +1,102 @@
+package analytics_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rd_station "github.com/verbeux-ai/rd-station-go"
+	"github.com/verbeux-ai/rd-station-go/analytics"
+)
+
+func ptr(s string) *string {
+	return &s
+}
+
+func testDeals() []rd_station.Deal {
+	return []rd_station.Deal{
+		{
+			ID:          "won",
+			AmountTotal: 1000,
+			CreatedAt:   "2024-01-01T00:00:00Z",
+			ClosedAt:    "2024-01-11T00:00:00Z",
+			DealStage:   rd_station.DealStage{ID: "proposal", DealPipelineID: "sales"},
+			User:        rd_station.User{ID: "ana"},
+			Win:         "true",
+			DealStageHistories: []rd_station.DealStageHistoryResponse{
+				{DealStageID: "proposal", StartDate: "2024-01-03T00:00:00Z", EndDate: ptr("2024-01-11T00:00:00Z")},
+				{DealStageID: "lead", StartDate: "2024-01-01T00:00:00Z", EndDate: ptr("2024-01-03T00:00:00Z")},
+			},
+		},
+		{
+			ID:          "lost",
+			AmountTotal: 500,
+			CreatedAt:   "2024-01-01T00:00:00Z",
+			ClosedAt:    "2024-01-05T00:00:00Z",
+			DealStage:   rd_station.DealStage{ID: "lead", DealPipelineID: "sales"},
+			User:        rd_station.User{ID: "bruno"},
+			Win:         "false",
+			DealStageHistories: []rd_station.DealStageHistoryResponse{
+				{DealStageID: "lead", StartDate: "2024-01-01T00:00:00Z", EndDate: ptr("2024-01-05T00:00:00Z")},
+			},
+		},
+		{
+			ID:             "open",
+			AmountTotal:    300,
+			CreatedAt:      "2024-01-10T00:00:00Z",
+			PredictionDate: "2024-03-01",
+			DealStage:      rd_station.DealStage{ID: "lead", DealPipelineID: "sales"},
+			DealSource:     &rd_station.DealSourceResponse{ID: "site"},
+			User:           rd_station.User{ID: "ana"},
+			DealStageHistories: []rd_station.DealStageHistoryResponse{
+				{DealStageID: "lead", StartDate: "2024-01-10T00:00:00Z"},
+			},
+		},
+	}
+}
+
+func fixedNow() time.Time {
+	return time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)
+}
+
+func TestTimeInStage(t *testing.T) {
+	report := analytics.TimeInStage(testDeals(), analytics.WithNow(fixedNow))
+	require.Len(t, report, 2)
+
+	day := 24 * time.Hour
+	assert.Equal(t, analytics.StageDuration{StageID: "lead", Deals: 3, Total: 8 * day, Average: 8 * day / 3, Median: 2 * day}, report[0])
+	assert.Equal(t, analytics.StageDuration{StageID: "proposal", Deals: 1, Total: 8 * day, Average: 8 * day, Median: 8 * day}, report[1])
+}
+
+func TestStageConversions(t *testing.T) {
+	report := analytics.StageConversions(testDeals())
+
+	assert.Equal(t, []analytics.StageConversion{
+		{FromStageID: "lead", ToStageID: "proposal", Entered: 3, Converted: 1, Rate: 1.0 / 3},
+	}, report)
+}
+
+func TestWinRates(t *testing.T) {
+	assert.Equal(t, []analytics.WinRate{
+		{Key: "ana", Won: 1, Open: 1, Rate: 1},
+		{Key: "bruno", Lost: 1, Rate: 0},
+	}, analytics.WinRates(testDeals(), analytics.ByUser))
+
+	assert.Equal(t, []analytics.WinRate{
+		{Key: "sales", Won: 1, Lost: 1, Open: 1, Rate: 0.5},
+	}, analytics.WinRates(testDeals(), analytics.ByPipeline))
+}
+
+func TestAverageCycle(t *testing.T) {
+	day := 24 * time.Hour
+	assert.Equal(t, analytics.CycleLength{Deals: 2, Average: 7 * day, Won: 10 * day, Lost: 4 * day}, analytics.AverageCycle(testDeals()))
+}
+
+func TestForecast(t *testing.T) {
+	saoPaulo := time.FixedZone("BRT", -3*60*60)
+
+	assert.Equal(t, []analytics.ForecastMonth{
+		{Month: "2024-03", Deals: 1, Amount: 300},
+	}, analytics.Forecast(testDeals(), analytics.WithLocation(saoPaulo)))
+}
@@ -0,0 +1,173 @@
+package analytics
+
+import (
+	"sort"
+	"time"
+
+	rd_station "github.com/verbeux-ai/rd-station-go"
+)
+
+// GroupBy extracts the grouping key of a deal for win rate reports
+type GroupBy func(deal rd_station.Deal) string
+
+// ByPipeline groups deals by the pipeline of their current stage
+func ByPipeline(deal rd_station.Deal) string {
+	return deal.DealStage.DealPipelineID
+}
+
+// ByUser groups deals by their owner
+func ByUser(deal rd_station.Deal) string {
+	return deal.User.ID
+}
+
+// BySource groups deals by their source
+func BySource(deal rd_station.Deal) string {
+	if deal.DealSource == nil {
+		return ""
+	}
+
+	return deal.DealSource.ID
+}
+
+// WinRate is the outcome of the deals of a group. Rate only considers closed deals.
+type WinRate struct {
+	Key  string  `json:"key"`
+	Won  int     `json:"won"`
+	Lost int     `json:"lost"`
+	Open int     `json:"open"`
+	Rate float64 `json:"rate"`
+}
+
+// CycleLength is the time between creation and closing of closed deals
+type CycleLength struct {
+	Deals   int           `json:"deals"`
+	Average time.Duration `json:"average"`
+	Won     time.Duration `json:"won"`
+	Lost    time.Duration `json:"lost"`
+}
+
+// ForecastMonth is the amount of open deals expected to close in a month
+type ForecastMonth struct {
+	// Month is formatted as "2006-01"
+	Month  string  `json:"month"`
+	Deals  int     `json:"deals"`
+	Amount float64 `json:"amount"`
+}
+
+// WinRates computes won, lost and open deals for each group
+func WinRates(deals []rd_station.Deal, groupBy GroupBy) []WinRate {
+	groups := map[string]*WinRate{}
+	for _, deal := range deals {
+		key := groupBy(deal)
+		group, ok := groups[key]
+		if !ok {
+			group = &WinRate{Key: key}
+			groups[key] = group
+		}
+
+		switch deal.Win {
+		case dealWon:
+			group.Won++
+		case dealLost:
+			group.Lost++
+		default:
+			group.Open++
+		}
+	}
+
+	report := make([]WinRate, 0, len(groups))
+	for _, group := range groups {
+		group.Rate = ratio(group.Won, group.Won+group.Lost)
+		report = append(report, *group)
+	}
+
+	sort.Slice(report, func(i, j int) bool { return report[i].Key < report[j].Key })
+
+	return report
+}
+
+// AverageCycle computes the average time closed deals took from creation to closing
+func AverageCycle(deals []rd_station.Deal) CycleLength {
+	var total, won, lost time.Duration
+	var count, wonCount, lostCount int
+
+	for _, deal := range deals {
+		if deal.Win != dealWon && deal.Win != dealLost {
+			continue
+		}
+
+		createdAt, ok := parseTime(deal.CreatedAt, time.UTC)
+		if !ok {
+			continue
+		}
+
+		closedAt, ok := parseTime(deal.ClosedAt, time.UTC)
+		if !ok || closedAt.Before(createdAt) {
+			continue
+		}
+
+		cycle := closedAt.Sub(createdAt)
+		total += cycle
+		count++
+
+		if deal.Win == dealWon {
+			won += cycle
+			wonCount++
+		} else {
+			lost += cycle
+			lostCount++
+		}
+	}
+
+	return CycleLength{
+		Deals:   count,
+		Average: average(total, count),
+		Won:     average(won, wonCount),
+		Lost:    average(lost, lostCount),
+	}
+}
+
+// Forecast sums the total amount of open deals by the month of their prediction date.
+// Deals without a prediction date are left out.
+func Forecast(deals []rd_station.Deal, opts ...Option) []ForecastMonth {
+	c := newConfig(opts)
+
+	months := map[string]*ForecastMonth{}
+	for _, deal := range deals {
+		if deal.Win == dealWon || deal.Win == dealLost {
+			continue
+		}
+
+		predictionDate, ok := parseTime(deal.PredictionDate, c.location)
+		if !ok {
+			continue
+		}
+
+		key := predictionDate.In(c.location).Format("2006-01")
+		month, ok := months[key]
+		if !ok {
+			month = &ForecastMonth{Month: key}
+			months[key] = month
+		}
+
+		month.Deals++
+		month.Amount += deal.AmountTotal
+	}
+
+	report := make([]ForecastMonth, 0, len(months))
+	for _, month := range months {
+		report = append(report, *month)
+	}
+
+	sort.Slice(report, func(i, j int) bool { return report[i].Month < report[j].Month })
+
+	return report
+}
+
+func average(total time.Duration, count int) time.Duration {
+	if count == 0 {
+		return 0
+	}
+
+	return total / time.Duration(count)
+}
@@ -0,0 +1,150 @@
+package analytics
+
+import (
+	"sort"
+	"time"
+
+	rd_station "github.com/verbeux-ai/rd-station-go"
+)
+
+// StageDuration is how long deals stayed in a stage
+type StageDuration struct {
+	StageID string `json:"stage_id"`
+	// Deals is the number of deals that went through the stage
+	Deals   int           `json:"deals"`
+	Total   time.Duration `json:"total"`
+	Average time.Duration `json:"average"`
+	Median  time.Duration `json:"median"`
+}
+
+// StageConversion is how many deals that entered a stage moved to another one next
+type StageConversion struct {
+	FromStageID string  `json:"from_stage_id"`
+	ToStageID   string  `json:"to_stage_id"`
+	Entered     int     `json:"entered"`
+	Converted   int     `json:"converted"`
+	Rate        float64 `json:"rate"`
+}
+
+type stageVisit struct {
+	stageID string
+	start   time.Time
+	end     time.Time
+}
+
+// TimeInStage computes the time deals spent in each stage from their stage histories.
+// Stages that haven't ended yet are measured until now.
+func TimeInStage(deals []rd_station.Deal, opts ...Option) []StageDuration {
+	c := newConfig(opts)
+	now := c.now()
+
+	durations := map[string][]time.Duration{}
+	for _, deal := range deals {
+		for _, visit := range stageVisits(deal, now) {
+			durations[visit.stageID] = append(durations[visit.stageID], visit.end.Sub(visit.start))
+		}
+	}
+
+	report := make([]StageDuration, 0, len(durations))
+	for stageID, values := range durations {
+		sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+
+		var total time.Duration
+		for _, value := range values {
+			total += value
+		}
+
+		report = append(report, StageDuration{
+			StageID: stageID,
+			Deals:   len(values),
+			Total:   total,
+			Average: total / time.Duration(len(values)),
+			Median:  median(values),
+		})
+	}
+
+	sort.Slice(report, func(i, j int) bool { return report[i].StageID < report[j].StageID })
+
+	return report
+}
+
+// StageConversions computes, for every pair of consecutive stages in the deals histories, how many
+// of the deals that entered the first stage moved to the second one
+func StageConversions(deals []rd_station.Deal) []StageConversion {
+	entered := map[string]int{}
+	converted := map[[2]string]int{}
+
+	for _, deal := range deals {
+		visits := stageVisits(deal, time.Time{})
+
+		seen := map[string]bool{}
+		for i, visit := range visits {
+			if !seen[visit.stageID] {
+				seen[visit.stageID] = true
+				entered[visit.stageID]++
+			}
+
+			if i+1 < len(visits) && visits[i+1].stageID != visit.stageID {
+				converted[[2]string{visit.stageID, visits[i+1].stageID}]++
+			}
+		}
+	}
+
+	report := make([]StageConversion, 0, len(converted))
+	for pair, count := range converted {
+		report = append(report, StageConversion{
+			FromStageID: pair[0],
+			ToStageID:   pair[1],
+			Entered:     entered[pair[0]],
+			Converted:   count,
+			Rate:        ratio(count, entered[pair[0]]),
+		})
+	}
+
+	sort.Slice(report, func(i, j int) bool {
+		if report[i].FromStageID != report[j].FromStageID {
+			return report[i].FromStageID < report[j].FromStageID
+		}
+		return report[i].ToStageID < report[j].ToStageID
+	})
+
+	return report
+}
+
+// stageVisits returns the deal stage histories ordered by start date, skipping the ones with
+// unparseable dates. Open visits end at now.
+func stageVisits(deal rd_station.Deal, now time.Time) []stageVisit {
+	visits := make([]stageVisit, 0, len(deal.DealStageHistories))
+	for _, history := range deal.DealStageHistories {
+		start, ok := parseTime(history.StartDate, time.UTC)
+		if !ok {
+			continue
+		}
+
+		end := now
+		if history.EndDate != nil {
+			if parsed, ok := parseTime(*history.EndDate, time.UTC); ok {
+				end = parsed
+			}
+		}
+
+		if end.Before(start) {
+			end = start
+		}
+
+		visits = append(visits, stageVisit{stageID: history.DealStageID, start: start, end: end})
+	}
+
+	sort.SliceStable(visits, func(i, j int) bool { return visits[i].start.Before(visits[j].start) })
+
+	return visits
+}
+
+func median(sorted []time.Duration) time.Duration {
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[middle]
+	}
+
+	return (sorted[middle-1] + sorted[middle]) / 2
+}
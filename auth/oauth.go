@@ -0,0 +1,143 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	defaultAuthorizeURL = "https://api.rd.services/auth/dialog"
+	defaultTokenURL     = "https://api.rd.services/auth/token"
+)
+
+// Config holds the OAuth2 credentials of an RD Station app
+type Config struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+
+	// AuthorizeURL and TokenURL default to the RD Station Marketing endpoints
+	AuthorizeURL string
+	TokenURL     string
+
+	// HttpClient defaults to http.DefaultClient
+	HttpClient *http.Client
+
+	// Timeout bounds background refreshes, which don't inherit a caller context
+	Timeout time.Duration
+
+	// OnSaveError is called when a refreshed token can't be saved to the store, with an error
+	// wrapping ErrTokenNotSaved. The token is used anyway, since the refresh token it replaced
+	// may no longer be valid. By default the failure is logged with slog.Default.
+	OnSaveError func(err error)
+}
+
+type tokenRequest struct {
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	Code         string `json:"code,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
+
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// AuthCodeURL returns the URL the user must visit to authorize the app
+func (c Config) AuthCodeURL(state string) string {
+	authorizeURL := c.AuthorizeURL
+	if authorizeURL == "" {
+		authorizeURL = defaultAuthorizeURL
+	}
+
+	query := url.Values{}
+	query.Set("client_id", c.ClientID)
+	query.Set("redirect_uri", c.RedirectURL)
+	if state != "" {
+		query.Set("state", state)
+	}
+
+	return authorizeURL + "?" + query.Encode()
+}
+
+// Exchange trades the authorization code received on the redirect URL for a token
+func (c Config) Exchange(ctx context.Context, code string) (*Token, error) {
+	return c.requestToken(ctx, tokenRequest{
+		ClientID:     c.ClientID,
+		ClientSecret: c.ClientSecret,
+		Code:         code,
+	})
+}
+
+// RefreshToken trades a refresh token for a new token
+func (c Config) RefreshToken(ctx context.Context, refreshToken string) (*Token, error) {
+	return c.requestToken(ctx, tokenRequest{
+		ClientID:     c.ClientID,
+		ClientSecret: c.ClientSecret,
+		RefreshToken: refreshToken,
+	})
+}
+
+func (c Config) requestToken(ctx context.Context, payload tokenRequest) (*Token, error) {
+	tokenURL := c.TokenURL
+	if tokenURL == "" {
+		tokenURL = defaultTokenURL
+	}
+
+	httpClient := c.HttpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrTokenRequestFailed, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, readErr := io.ReadAll(resp.Body)
+		if readErr != nil {
+			return nil, fmt.Errorf("%w (status: %d), read response body error: %w", ErrTokenRequestFailed, resp.StatusCode, readErr)
+		}
+		return nil, fmt.Errorf("%w (status: %d): %w", ErrTokenRequestFailed, resp.StatusCode, errors.New(string(bodyBytes)))
+	}
+
+	var responsePayload tokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&responsePayload); err != nil {
+		return nil, fmt.Errorf("%w: error decoding token response: %w", ErrTokenRequestFailed, err)
+	}
+
+	token := &Token{
+		AccessToken:  responsePayload.AccessToken,
+		RefreshToken: responsePayload.RefreshToken,
+	}
+	if token.RefreshToken == "" {
+		token.RefreshToken = payload.RefreshToken
+	}
+	if responsePayload.ExpiresIn > 0 {
+		token.ExpiresAt = time.Now().Add(time.Duration(responsePayload.ExpiresIn) * time.Second)
+	}
+
+	return token, nil
+}
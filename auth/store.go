@@ -0,0 +1,116 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// TokenStore persists refreshed tokens so they survive restarts. Load returns a nil token
+// when nothing was stored yet.
+type TokenStore interface {
+	Load(ctx context.Context) (*Token, error)
+	Save(ctx context.Context, token *Token) error
+}
+
+// MemoryStore keeps the token in memory
+type MemoryStore struct {
+	mu    sync.Mutex
+	token *Token
+}
+
+// NewMemoryStore creates a store holding the initial token, which may be nil
+func NewMemoryStore(token *Token) *MemoryStore {
+	return &MemoryStore{token: token}
+}
+
+func (s *MemoryStore) Load(context.Context) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.token, nil
+}
+
+func (s *MemoryStore) Save(_ context.Context, token *Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.token = token
+	return nil
+}
+
+// FileStore keeps the token as JSON in a file readable only by its owner
+type FileStore struct {
+	Path string
+	mu   sync.Mutex
+}
+
+// NewFileStore creates a store backed by the file at path
+func NewFileStore(path string) *FileStore {
+	return &FileStore{Path: path}
+}
+
+func (s *FileStore) Load(context.Context) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var token Token
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// Save writes to a temporary file first so a crash never leaves a truncated token behind
+func (s *FileStore) Save(_ context.Context, token *Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.Path), filepath.Base(s.Path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.Path)
+}
+
+// StoreFuncs adapts a pair of functions, e.g. backed by a database, to a TokenStore
+type StoreFuncs struct {
+	LoadFunc func(ctx context.Context) (*Token, error)
+	SaveFunc func(ctx context.Context, token *Token) error
+}
+
+func (s StoreFuncs) Load(ctx context.Context) (*Token, error) {
+	return s.LoadFunc(ctx)
+}
+
+func (s StoreFuncs) Save(ctx context.Context, token *Token) error {
+	return s.SaveFunc(ctx, token)
+}
@@ -0,0 +1,194 @@
+// Package auth implements the OAuth2 flow used by the RD Station Marketing API: the authorization
+// code exchange, token sources that refresh expired access tokens and stores to persist them.
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+var (
+	ErrNoToken            = errors.New("no token available")
+	ErrTokenRequestFailed = errors.New("token request failed")
+	ErrTokenNotSaved      = errors.New("refreshed token could not be saved")
+)
+
+// expiryLeeway refreshes tokens slightly before they expire to absorb clock skew and latency
+const expiryLeeway = 30 * time.Second
+
+type Token struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// Valid reports whether the access token is present and not about to expire.
+// Tokens without an expiration are always valid.
+func (t *Token) Valid() bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+
+	return t.ExpiresAt.IsZero() || time.Now().Add(expiryLeeway).Before(t.ExpiresAt)
+}
+
+// TokenSource supplies access tokens for authenticated requests
+type TokenSource interface {
+	Token(ctx context.Context) (*Token, error)
+}
+
+// Refresher is implemented by token sources that can replace a token rejected by the API.
+// stale is the token that was rejected, so concurrent callers refresh it only once.
+type Refresher interface {
+	Refresh(ctx context.Context, stale *Token) (*Token, error)
+}
+
+// StaticTokenSource always returns the same access token
+func StaticTokenSource(accessToken string) TokenSource {
+	return staticTokenSource{token: &Token{AccessToken: accessToken}}
+}
+
+type staticTokenSource struct {
+	token *Token
+}
+
+func (s staticTokenSource) Token(context.Context) (*Token, error) {
+	if s.token.AccessToken == "" {
+		return nil, ErrNoToken
+	}
+
+	return s.token, nil
+}
+
+// RefreshingTokenSource returns the stored token while it's valid and refreshes it with the
+// refresh token when it expires or is rejected. Concurrent refreshes share a single request to
+// the token endpoint, and refreshed tokens are saved to the store.
+type RefreshingTokenSource struct {
+	config Config
+	store  TokenStore
+
+	mu       sync.Mutex
+	token    *Token
+	inflight *refreshCall
+}
+
+type refreshCall struct {
+	done  chan struct{}
+	token *Token
+	err   error
+}
+
+// NewRefreshingTokenSource creates a token source that starts from the token in the store
+func NewRefreshingTokenSource(config Config, store TokenStore) *RefreshingTokenSource {
+	return &RefreshingTokenSource{
+		config: config,
+		store:  store,
+	}
+}
+
+func (s *RefreshingTokenSource) Token(ctx context.Context) (*Token, error) {
+	s.mu.Lock()
+	token := s.token
+	s.mu.Unlock()
+
+	if token.Valid() {
+		return token, nil
+	}
+
+	if token == nil {
+		stored, err := s.store.Load(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		s.mu.Lock()
+		if s.token == nil {
+			s.token = stored
+		}
+		token = s.token
+		s.mu.Unlock()
+
+		if token.Valid() {
+			return token, nil
+		}
+	}
+
+	return s.Refresh(ctx, token)
+}
+
+func (s *RefreshingTokenSource) Refresh(ctx context.Context, stale *Token) (*Token, error) {
+	s.mu.Lock()
+	if s.token != nil && s.token != stale && s.token.Valid() {
+		// someone else already replaced the stale token
+		token := s.token
+		s.mu.Unlock()
+		return token, nil
+	}
+
+	call := s.inflight
+	if call == nil {
+		call = &refreshCall{done: make(chan struct{})}
+		s.inflight = call
+		go s.refresh(call, s.token)
+	}
+	s.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-call.done:
+		return call.token, call.err
+	}
+}
+
+// refresh runs detached from the callers contexts, so one caller giving up doesn't fail the others
+func (s *RefreshingTokenSource) refresh(call *refreshCall, current *Token) {
+	ctx := context.Background()
+	if s.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.config.Timeout)
+		defer cancel()
+	}
+
+	call.token, call.err = s.doRefresh(ctx, current)
+
+	s.mu.Lock()
+	if call.err == nil {
+		s.token = call.token
+	}
+	s.inflight = nil
+	s.mu.Unlock()
+
+	close(call.done)
+}
+
+func (s *RefreshingTokenSource) doRefresh(ctx context.Context, current *Token) (*Token, error) {
+	if current == nil || current.RefreshToken == "" {
+		return nil, ErrNoToken
+	}
+
+	token, err := s.config.RefreshToken(ctx, current.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// the refresh token may have been rotated, so losing the new token could lock the client out
+	if err := s.store.Save(ctx, token); err != nil {
+		s.reportSaveError(fmt.Errorf("%w: %w", ErrTokenNotSaved, err))
+	}
+
+	return token, nil
+}
+
+func (s *RefreshingTokenSource) reportSaveError(err error) {
+	if s.config.OnSaveError != nil {
+		s.config.OnSaveError(err)
+		return
+	}
+
+	slog.Default().Error("rd station token refresh", slog.Any("error", err))
+}
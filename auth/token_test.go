@@ -0,0 +1,139 @@
+package auth_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/verbeux-ai/rd-station-go/auth"
+)
+
+func tokenServer(t *testing.T, calls *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+
+		n := calls.Add(1)
+		time.Sleep(20 * time.Millisecond)
+
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token":  "access-" + payload["refresh_token"] + "-" + strconv.Itoa(int(n)),
+			"refresh_token": "refresh",
+			"expires_in":    86400,
+		})
+	}))
+}
+
+func TestRefreshingTokenSourceSingleFlight(t *testing.T) {
+	var calls atomic.Int32
+	server := tokenServer(t, &calls)
+	defer server.Close()
+
+	store := auth.NewMemoryStore(&auth.Token{AccessToken: "expired", RefreshToken: "refresh", ExpiresAt: time.Now().Add(-time.Hour)})
+	source := auth.NewRefreshingTokenSource(auth.Config{ClientID: "id", ClientSecret: "secret", TokenURL: server.URL}, store)
+
+	var wg sync.WaitGroup
+	tokens := make([]*auth.Token, 10)
+	for i := range tokens {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token, err := source.Token(context.Background())
+			assert.NoError(t, err)
+			tokens[i] = token
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+	for _, token := range tokens {
+		assert.Equal(t, "access-refresh-1", token.AccessToken)
+	}
+
+	stored, err := store.Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "access-refresh-1", stored.AccessToken)
+}
+
+func TestRefreshingTokenSourceRefreshStaleOnce(t *testing.T) {
+	var calls atomic.Int32
+	server := tokenServer(t, &calls)
+	defer server.Close()
+
+	store := auth.NewMemoryStore(&auth.Token{AccessToken: "rejected", RefreshToken: "refresh"})
+	source := auth.NewRefreshingTokenSource(auth.Config{TokenURL: server.URL}, store)
+
+	stale, err := source.Token(context.Background())
+	require.NoError(t, err)
+
+	first, err := source.Refresh(context.Background(), stale)
+	require.NoError(t, err)
+	second, err := source.Refresh(context.Background(), stale)
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+type failingStore struct {
+	*auth.MemoryStore
+}
+
+func (s failingStore) Save(context.Context, *auth.Token) error {
+	return errors.New("disk full")
+}
+
+func TestRefreshingTokenSourceKeepsUnsavedToken(t *testing.T) {
+	var calls atomic.Int32
+	server := tokenServer(t, &calls)
+	defer server.Close()
+
+	var saveErr error
+	store := failingStore{auth.NewMemoryStore(&auth.Token{AccessToken: "expired", RefreshToken: "refresh", ExpiresAt: time.Now().Add(-time.Hour)})}
+	source := auth.NewRefreshingTokenSource(auth.Config{
+		TokenURL:    server.URL,
+		OnSaveError: func(err error) { saveErr = err },
+	}, store)
+
+	token, err := source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "access-refresh-1", token.AccessToken)
+	assert.ErrorIs(t, saveErr, auth.ErrTokenNotSaved)
+
+	// the refreshed token is kept, no second refresh is needed
+	token, err = source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "access-refresh-1", token.AccessToken)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestFileStore(t *testing.T) {
+	store := auth.NewFileStore(filepath.Join(t.TempDir(), "token.json"))
+
+	token, err := store.Load(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, token)
+
+	expected := &auth.Token{AccessToken: "access", RefreshToken: "refresh", ExpiresAt: time.Now().Add(time.Hour).UTC().Truncate(time.Second)}
+	require.NoError(t, store.Save(context.Background(), expected))
+
+	token, err = store.Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, expected, token)
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	config := auth.Config{ClientID: "id", RedirectURL: "https://example.com/callback"}
+
+	assert.Equal(t, "https://api.rd.services/auth/dialog?client_id=id&redirect_uri=https%3A%2F%2Fexample.com%2Fcallback&state=xyz", config.AuthCodeURL("xyz"))
+}
@@ -0,0 +1,140 @@
+package rd_station
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/verbeux-ai/rd-station-go/auth"
+)
+
+const redacted = "REDACTED"
+
+var (
+	// ErrAuthentication wraps the errors of the Authenticator, which are never retried
+	ErrAuthentication = errors.New("failed to authenticate request")
+)
+
+// sensitiveParams are the query parameters masked by RedactURL
+var sensitiveParams = []string{"token", "access_token", "refresh_token", "client_secret", "code"}
+
+// Authenticator adds credentials to every request sent by the client
+type Authenticator interface {
+	Authenticate(ctx context.Context, req *http.Request) error
+}
+
+// RefreshableAuthenticator is an Authenticator that can replace credentials rejected by the API.
+// The client calls Refresh once when a request is answered with 401 and sends it again.
+type RefreshableAuthenticator interface {
+	Authenticator
+	Refresh(ctx context.Context, rejected *http.Request) error
+}
+
+type queryTokenAuthenticator struct {
+	token string
+}
+
+// QueryTokenAuthenticator sends the token in the "token" query parameter, as required by the CRM API
+func QueryTokenAuthenticator(token string) Authenticator {
+	return queryTokenAuthenticator{token: token}
+}
+
+func (a queryTokenAuthenticator) Authenticate(_ context.Context, req *http.Request) error {
+	query := req.URL.Query()
+	query.Set("token", a.token)
+	req.URL.RawQuery = query.Encode()
+
+	return nil
+}
+
+type bearerAuthenticator struct {
+	token string
+}
+
+// BearerAuthenticator sends the token in the Authorization header
+func BearerAuthenticator(token string) Authenticator {
+	return bearerAuthenticator{token: token}
+}
+
+func (a bearerAuthenticator) Authenticate(_ context.Context, req *http.Request) error {
+	req.Header.Set("Authorization", "Bearer "+a.token)
+
+	return nil
+}
+
+type oauth2Authenticator struct {
+	source auth.TokenSource
+}
+
+// OAuth2Authenticator sends access tokens from the token source in the Authorization header.
+// Rejected tokens are refreshed when the source implements auth.Refresher.
+func OAuth2Authenticator(source auth.TokenSource) RefreshableAuthenticator {
+	return oauth2Authenticator{source: source}
+}
+
+func (a oauth2Authenticator) Authenticate(ctx context.Context, req *http.Request) error {
+	token, err := a.source.Token(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting access token: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+
+	return nil
+}
+
+func (a oauth2Authenticator) Refresh(ctx context.Context, rejected *http.Request) error {
+	refresher, ok := a.source.(auth.Refresher)
+	if !ok {
+		return errors.New("token source can't refresh tokens")
+	}
+
+	current, err := a.source.Token(ctx)
+	if err != nil {
+		return err
+	}
+
+	if "Bearer "+current.AccessToken != rejected.Header.Get("Authorization") {
+		// the rejected token was already replaced by a concurrent request
+		return nil
+	}
+
+	_, err = refresher.Refresh(ctx, current)
+	return err
+}
+
+// RedactURL masks the credentials in the query string of a URL, e.g. the CRM token
+func RedactURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	query := parsed.Query()
+	changed := false
+	for _, param := range sensitiveParams {
+		if query.Has(param) {
+			query.Set(param, redacted)
+			changed = true
+		}
+	}
+
+	if !changed {
+		return rawURL
+	}
+
+	parsed.RawQuery = query.Encode()
+	return parsed.String()
+}
+
+// redactError masks the credentials of the URL that net/http includes in its errors
+func redactError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		urlErr.URL = RedactURL(urlErr.URL)
+	}
+
+	return err
+}
@@ -0,0 +1,70 @@
+package rd_station_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rd_station "github.com/verbeux-ai/rd-station-go"
+)
+
+func TestRequestErrorsDoNotLeakToken(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	client := rd_station.NewClient(rd_station.WithBaseUrl(server.URL), rd_station.WithToken("super-secret"))
+
+	_, err := client.ListDealsFilter(context.Background(), rd_station.ListDealsFilterRequest{Name: "deal"})
+	require.ErrorIs(t, err, rd_station.ErrRequestFailed)
+	assert.NotContains(t, err.Error(), "super-secret")
+	assert.Contains(t, err.Error(), "token=REDACTED")
+}
+
+func TestBearerAuthenticator(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		assert.False(t, r.URL.Query().Has("token"))
+		_, _ = w.Write([]byte(`{"deals":[]}`))
+	}))
+	defer server.Close()
+
+	client := rd_station.NewClient(rd_station.WithBaseUrl(server.URL), rd_station.WithAuthenticator(rd_station.BearerAuthenticator("secret")))
+
+	_, err := client.ListDealsFilter(context.Background(), rd_station.ListDealsFilterRequest{})
+	require.NoError(t, err)
+}
+
+type failingAuthenticator struct {
+	calls int
+}
+
+func (a *failingAuthenticator) Authenticate(ctx context.Context, req *http.Request) error {
+	a.calls++
+	return errors.New("invalid_grant: refresh token revoked")
+}
+
+func TestAuthenticationErrorsAreNotRetried(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	authenticator := &failingAuthenticator{}
+	client := rd_station.NewClient(
+		rd_station.WithBaseUrl(server.URL),
+		rd_station.WithAuthenticator(authenticator),
+		rd_station.WithRetryPolicy(rd_station.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond}),
+	)
+
+	_, err := client.GetDeal(context.Background(), "1")
+	require.ErrorIs(t, err, rd_station.ErrAuthentication)
+	assert.Equal(t, 1, authenticator.calls)
+}
+
+func TestRedactURL(t *testing.T) {
+	assert.Equal(t, "https://crm.rdstation.com/api/v1/deals?page=1&token=REDACTED", rd_station.RedactURL("https://crm.rdstation.com/api/v1/deals?page=1&token=secret"))
+	assert.Equal(t, "https://crm.rdstation.com/api/v1/deals", rd_station.RedactURL("https://crm.rdstation.com/api/v1/deals"))
+}
@@ -0,0 +1,309 @@
+package rd_station
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultCacheTTL      = 5 * time.Minute
+	defaultCacheCapacity = 256
+)
+
+// CachedResource is reference data the cache keeps, named after its API path
+type CachedResource string
+
+const (
+	CachedDealPipelines   CachedResource = "deal_pipelines"
+	CachedDealStages      CachedResource = "deal_stages"
+	CachedDealLostReasons CachedResource = "deal_lost_reasons"
+	CachedUsers           CachedResource = "users"
+	CachedCustomFields    CachedResource = "custom_fields"
+)
+
+// cachedResources maps the endpoints of the reference data to their resource
+var cachedResources = map[string]CachedResource{
+	listDealPipelinesEndpoint.path:   CachedDealPipelines,
+	listDealStagesEndpoint.path:      CachedDealStages,
+	listDealLostReasonsEndpoint.path: CachedDealLostReasons,
+	listUsersEndpoint.path:           CachedUsers,
+	listCustomFieldsEndpoint.path:    CachedCustomFields,
+}
+
+// cacheDependencies lists the resources that embed another one, e.g.: pipelines list their stages
+var cacheDependencies = map[CachedResource][]CachedResource{
+	CachedDealStages:    {CachedDealPipelines},
+	CachedDealPipelines: {CachedDealStages},
+}
+
+// CacheEntry is a cached response
+type CacheEntry struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	StoredAt   time.Time
+}
+
+// CacheBackend stores the cached responses. Implementations must be safe for concurrent use.
+type CacheBackend interface {
+	Get(ctx context.Context, key string) (CacheEntry, bool)
+	Set(ctx context.Context, key string, entry CacheEntry)
+	// DeletePrefix removes every entry whose key starts with prefix
+	DeletePrefix(ctx context.Context, prefix string)
+}
+
+// CacheOptions configures a Cache, zero values use the defaults
+type CacheOptions struct {
+	// Backend stores the entries, an in-memory LRU of 256 entries by default
+	Backend CacheBackend
+	// TTL is how long entries are served without asking the API, 5 minutes by default.
+	// Expired entries with an ETag or Last-Modified header are revalidated with a
+	// conditional request instead of being downloaded again.
+	TTL time.Duration
+	// Namespace prefixes the keys. Clients of different accounts sharing a backend must
+	// use different namespaces, the clients of a ClientPool get one per tenant.
+	Namespace string
+}
+
+// Cache keeps the responses of the reference data GET endpoints: pipelines, stages, lost
+// reasons, users and custom fields. Successful writes sent through the cache invalidate
+// the resource they touch.
+type Cache struct {
+	backend   CacheBackend
+	ttl       time.Duration
+	namespace string
+}
+
+// NewCache creates a cache to be used with WithCache
+func NewCache(opts CacheOptions) *Cache {
+	if opts.Backend == nil {
+		opts.Backend = NewLRUCache(defaultCacheCapacity)
+	}
+	if opts.TTL <= 0 {
+		opts.TTL = defaultCacheTTL
+	}
+
+	return &Cache{
+		backend:   opts.Backend,
+		ttl:       opts.TTL,
+		namespace: opts.Namespace,
+	}
+}
+
+// WithCache serves the reference data from the cache
+func WithCache(cache *Cache) Option {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+// withNamespace returns a cache sharing the backend and TTL, with other keys
+func (c *Cache) withNamespace(namespace string) *Cache {
+	return &Cache{backend: c.backend, ttl: c.ttl, namespace: namespace}
+}
+
+// Invalidate removes the cached responses of the resources
+func (c *Cache) Invalidate(ctx context.Context, resources ...CachedResource) {
+	for _, resource := range resources {
+		c.backend.DeletePrefix(ctx, c.resourcePrefix(resource))
+	}
+}
+
+// Clear removes every cached response of the namespace
+func (c *Cache) Clear(ctx context.Context) {
+	c.backend.DeletePrefix(ctx, c.namespace)
+}
+
+// Middleware serves the GET requests of the reference data from the cache and invalidates
+// them on successful writes. Requests not built by the client pass through.
+func (c *Cache) Middleware() Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			info, ok := RequestInfoFromContext(req.Context())
+			if !ok {
+				return next.Do(req)
+			}
+
+			resource, ok := resourceOf(info.Endpoint)
+			if !ok {
+				return next.Do(req)
+			}
+
+			if req.Method != http.MethodGet {
+				resp, err := next.Do(req)
+				if err == nil && resp.StatusCode >= 200 && resp.StatusCode < 300 {
+					c.Invalidate(req.Context(), resource)
+					c.Invalidate(req.Context(), cacheDependencies[resource]...)
+				}
+				return resp, err
+			}
+
+			return c.get(next, req, c.resourcePrefix(resource)+info.Endpoint+"?"+req.URL.RawQuery)
+		})
+	}
+}
+
+func (c *Cache) get(next Doer, req *http.Request, key string) (*http.Response, error) {
+	ctx := req.Context()
+	entry, cached := c.backend.Get(ctx, key)
+	if cached && time.Since(entry.StoredAt) < c.ttl {
+		return cachedResponse(req, entry), nil
+	}
+
+	etag, lastModified := entry.Header.Get("ETag"), entry.Header.Get("Last-Modified")
+	revalidate := cached && (etag != "" || lastModified != "")
+	if revalidate {
+		req = req.Clone(ctx)
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		if lastModified != "" {
+			req.Header.Set("If-Modified-Since", lastModified)
+		}
+	}
+
+	resp, err := next.Do(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if revalidate && resp.StatusCode == http.StatusNotModified {
+		drainAndClose(resp)
+		entry.StoredAt = time.Now()
+		c.backend.Set(ctx, key, entry)
+		return cachedResponse(req, entry), nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	c.backend.Set(ctx, key, CacheEntry{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header.Clone(),
+		Body:       body,
+		StoredAt:   time.Now(),
+	})
+
+	return resp, nil
+}
+
+func (c *Cache) resourcePrefix(resource CachedResource) string {
+	return c.namespace + string(resource) + " "
+}
+
+// resourceOf returns the resource of the endpoint or of one of its entities
+func resourceOf(endpoint string) (CachedResource, bool) {
+	for list, resource := range cachedResources {
+		if endpoint == list || strings.HasPrefix(endpoint, list+"/") {
+			return resource, true
+		}
+	}
+
+	return "", false
+}
+
+func cachedResponse(req *http.Request, entry CacheEntry) *http.Response {
+	if stats := RequestStatsFromContext(req.Context()); stats != nil {
+		stats.Cached = true
+	}
+
+	return &http.Response{
+		Status:        http.StatusText(entry.StatusCode),
+		StatusCode:    entry.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        entry.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(entry.Body)),
+		ContentLength: int64(len(entry.Body)),
+		Request:       req,
+	}
+}
+
+// LRUCache is an in-memory CacheBackend that evicts the least recently used entries
+type LRUCache struct {
+	capacity int
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type lruItem struct {
+	key   string
+	entry CacheEntry
+}
+
+// NewLRUCache creates a backend holding up to capacity entries
+func NewLRUCache(capacity int) *LRUCache {
+	return &LRUCache{
+		capacity: max(capacity, 1),
+		order:    list.New(),
+		entries:  map[string]*list.Element{},
+	}
+}
+
+func (c *LRUCache) Get(_ context.Context, key string) (CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return CacheEntry{}, false
+	}
+	c.order.MoveToFront(element)
+
+	return element.Value.(*lruItem).entry, true
+}
+
+func (c *LRUCache) Set(_ context.Context, key string, entry CacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*lruItem).entry = entry
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&lruItem{key: key, entry: entry})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruItem).key)
+	}
+}
+
+func (c *LRUCache) DeletePrefix(_ context.Context, prefix string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, element := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			c.order.Remove(element)
+			delete(c.entries, key)
+		}
+	}
+}
+
+// Len returns the number of cached entries
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
@@ -0,0 +1,145 @@
+package rd_station_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rd_station "github.com/verbeux-ai/rd-station-go"
+)
+
+func TestCache(t *testing.T) {
+	var gets atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			gets.Add(1)
+			_, _ = w.Write([]byte(`{"deal_lost_reasons":[{"id":"1","name":"Price"}]}`))
+		case http.MethodPut:
+			_, _ = w.Write([]byte(`{"id":"1","name":"Too expensive"}`))
+		}
+	}))
+	defer server.Close()
+
+	cache := rd_station.NewCache(rd_station.CacheOptions{})
+	client := rd_station.NewClient(
+		rd_station.WithBaseUrl(server.URL),
+		rd_station.WithToken("secret"),
+		rd_station.WithCache(cache),
+	)
+	ctx := context.Background()
+
+	for range 3 {
+		reasons, err := client.ListDealLostReasons(ctx)
+		require.NoError(t, err)
+		require.Len(t, reasons.DealLostReasons, 1)
+		assert.Equal(t, "Price", reasons.DealLostReasons[0].Name)
+	}
+	assert.EqualValues(t, 1, gets.Load())
+
+	// writes through the same client invalidate the resource
+	_, err := client.UpdateDealLostReason(ctx, "1", rd_station.DealLostReasonRequest{
+		DealLostReason: rd_station.DealLostReasonRequestData{Name: "Too expensive"},
+	})
+	require.NoError(t, err)
+	_, err = client.ListDealLostReasons(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, gets.Load())
+
+	cache.Invalidate(ctx, rd_station.CachedDealLostReasons)
+	_, err = client.ListDealLostReasons(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, gets.Load())
+
+	// uncached endpoints always reach the API
+	_, err = client.GetDeal(ctx, "123")
+	require.NoError(t, err)
+	_, err = client.GetDeal(ctx, "123")
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, gets.Load())
+}
+
+func TestCacheRevalidation(t *testing.T) {
+	var gets, notModified atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gets.Add(1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`{"users":[{"id":"1","name":"Ana"}]}`))
+	}))
+	defer server.Close()
+
+	client := rd_station.NewClient(
+		rd_station.WithBaseUrl(server.URL),
+		rd_station.WithCache(rd_station.NewCache(rd_station.CacheOptions{TTL: 20 * time.Millisecond})),
+	)
+	ctx := context.Background()
+
+	_, err := client.ListUsers(ctx)
+	require.NoError(t, err)
+
+	time.Sleep(30 * time.Millisecond)
+	users, err := client.ListUsers(ctx)
+	require.NoError(t, err)
+	require.Len(t, users.Users, 1)
+	assert.Equal(t, "Ana", users.Users[0].Name)
+	assert.EqualValues(t, 2, gets.Load())
+	assert.EqualValues(t, 1, notModified.Load())
+
+	// the revalidated entry is fresh again
+	_, err = client.ListUsers(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, gets.Load())
+}
+
+func TestCacheKeysIncludeQuery(t *testing.T) {
+	var gets atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gets.Add(1)
+		_, _ = w.Write([]byte(`{"custom_fields":[{"id":"1","for":"` + r.URL.Query().Get("option") + `"}]}`))
+	}))
+	defer server.Close()
+
+	client := rd_station.NewClient(
+		rd_station.WithBaseUrl(server.URL),
+		rd_station.WithCache(rd_station.NewCache(rd_station.CacheOptions{})),
+	)
+	ctx := context.Background()
+
+	deal, err := client.ListCustomFields(ctx, rd_station.ListCustomFieldsFilterRequest{For: rd_station.CustomFieldForDeal})
+	require.NoError(t, err)
+	contact, err := client.ListCustomFields(ctx, rd_station.ListCustomFieldsFilterRequest{For: rd_station.CustomFieldForContact})
+	require.NoError(t, err)
+
+	assert.Equal(t, rd_station.CustomFieldForDeal, deal.CustomFields[0].For)
+	assert.Equal(t, rd_station.CustomFieldForContact, contact.CustomFields[0].For)
+	assert.EqualValues(t, 2, gets.Load())
+}
+
+func TestLRUCache(t *testing.T) {
+	ctx := context.Background()
+	cache := rd_station.NewLRUCache(2)
+
+	cache.Set(ctx, "a", rd_station.CacheEntry{Body: []byte("a")})
+	cache.Set(ctx, "b", rd_station.CacheEntry{Body: []byte("b")})
+	_, _ = cache.Get(ctx, "a")
+	cache.Set(ctx, "c", rd_station.CacheEntry{Body: []byte("c")})
+
+	_, ok := cache.Get(ctx, "b")
+	assert.False(t, ok, "the least recently used entry is evicted")
+	entry, ok := cache.Get(ctx, "a")
+	assert.True(t, ok)
+	assert.Equal(t, "a", string(entry.Body))
+
+	cache.DeletePrefix(ctx, "c")
+	assert.Equal(t, 1, cache.Len())
+}
@@ -0,0 +1,289 @@
+package rd_station
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	ErrCircuitOpen = errors.New("circuit breaker is open")
+)
+
+type CircuitState int
+
+const (
+	CircuitClosed CircuitState = iota
+	CircuitOpen
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+
+	return "unknown"
+}
+
+// CircuitBreakerSettings configures a CircuitBreaker, zero values use the defaults
+type CircuitBreakerSettings struct {
+	// Name identifies the breaker in OnStateChange, e.g.: the host or the tenant
+	Name string
+	// FailureThreshold is the number of consecutive failures that opens the circuit, 5 by default
+	FailureThreshold int
+	// OpenTimeout is how long the circuit stays open before letting a probe through, 30s by default
+	OpenTimeout time.Duration
+	// IsFailure decides whether an API call counts as a failure, by default errors and 5xx
+	// responses do. Canceled requests are never passed to it, they count as neither.
+	IsFailure func(resp *http.Response, err error) bool
+	// OnStateChange is called after every state change, outside the breaker lock
+	OnStateChange func(name string, from, to CircuitState)
+}
+
+// CircuitBreaker fails requests fast with ErrCircuitOpen after consecutive failures. Once
+// OpenTimeout passes, it half-opens and lets a single probe request through: the circuit
+// closes when it succeeds and opens again when it fails.
+type CircuitBreaker struct {
+	settings CircuitBreakerSettings
+
+	mu       sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+	// generation changes with the state, so late outcomes of requests let through before
+	// are ignored
+	generation uint64
+}
+
+// circuitTicket is the state a request was let through in
+type circuitTicket struct {
+	generation uint64
+	probe      bool
+}
+
+// NewCircuitBreaker creates a closed circuit breaker
+func NewCircuitBreaker(settings CircuitBreakerSettings) *CircuitBreaker {
+	if settings.FailureThreshold <= 0 {
+		settings.FailureThreshold = 5
+	}
+	if settings.OpenTimeout <= 0 {
+		settings.OpenTimeout = 30 * time.Second
+	}
+	if settings.IsFailure == nil {
+		settings.IsFailure = isCircuitFailure
+	}
+
+	return &CircuitBreaker{settings: settings}
+}
+
+// WithCircuitBreaker fails the requests of the client fast while the breaker is open. Share
+// the breaker between clients to trip them together, e.g.: all the clients of a tenant.
+// Each API call counts once, after its retries.
+func WithCircuitBreaker(breaker *CircuitBreaker) Option {
+	return func(c *Client) {
+		c.circuitBreaker = breaker.Middleware()
+	}
+}
+
+// WithCircuitBreakerGroup gives the client the breaker of its host in the group
+func WithCircuitBreakerGroup(group *CircuitBreakerGroup) Option {
+	return func(c *Client) {
+		c.circuitBreaker = group.Middleware()
+	}
+}
+
+// State returns the current state of the circuit
+func (b *CircuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.currentState()
+}
+
+// Middleware fails fast while the circuit is open and records the outcome of the requests
+func (b *CircuitBreaker) Middleware() Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			ticket, err := b.allow()
+			if err != nil {
+				return nil, err
+			}
+
+			// a panic leaves no outcome, release the probe so the circuit can't stay half-open
+			recorded := false
+			defer func() {
+				if !recorded {
+					b.release(ticket)
+				}
+			}()
+
+			resp, err := next.Do(req)
+			recorded = true
+
+			if errors.Is(err, context.Canceled) {
+				// the caller gave up, which says nothing about the API
+				b.release(ticket)
+			} else {
+				b.record(ticket, b.settings.IsFailure(resp, err))
+			}
+
+			return resp, err
+		})
+	}
+}
+
+func (b *CircuitBreaker) currentState() CircuitState {
+	if b.state == CircuitOpen && time.Since(b.openedAt) >= b.settings.OpenTimeout {
+		return CircuitHalfOpen
+	}
+
+	return b.state
+}
+
+func (b *CircuitBreaker) allow() (circuitTicket, error) {
+	b.mu.Lock()
+	from := b.state
+	probe := false
+
+	switch b.currentState() {
+	case CircuitOpen:
+		b.mu.Unlock()
+		return circuitTicket{}, ErrCircuitOpen
+	case CircuitHalfOpen:
+		if b.probing {
+			b.mu.Unlock()
+			return circuitTicket{}, ErrCircuitOpen
+		}
+		if b.state != CircuitHalfOpen {
+			b.state = CircuitHalfOpen
+			b.generation++
+		}
+		b.probing = true
+		probe = true
+	}
+
+	ticket := circuitTicket{generation: b.generation, probe: probe}
+	to := b.state
+	b.mu.Unlock()
+
+	b.notify(from, to)
+
+	return ticket, nil
+}
+
+// record counts the outcome of a request, unless the state changed since it was let through:
+// only the probe decides whether a half-open circuit closes
+func (b *CircuitBreaker) record(ticket circuitTicket, failed bool) {
+	b.mu.Lock()
+	if ticket.generation != b.generation {
+		b.mu.Unlock()
+		return
+	}
+	from := b.state
+
+	switch {
+	case b.state == CircuitHalfOpen:
+		b.probing = false
+		if failed {
+			b.open()
+		} else {
+			b.state = CircuitClosed
+			b.failures = 0
+			b.generation++
+		}
+	case !failed:
+		b.failures = 0
+	default:
+		b.failures++
+		if b.state == CircuitClosed && b.failures >= b.settings.FailureThreshold {
+			b.open()
+		}
+	}
+
+	to := b.state
+	b.mu.Unlock()
+
+	b.notify(from, to)
+}
+
+// release ends a request without an outcome, keeping the state and the failure count
+func (b *CircuitBreaker) release(ticket circuitTicket) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if ticket.probe && ticket.generation == b.generation {
+		b.probing = false
+	}
+}
+
+func (b *CircuitBreaker) open() {
+	b.state = CircuitOpen
+	b.openedAt = time.Now()
+	b.failures = 0
+	b.generation++
+}
+
+func (b *CircuitBreaker) notify(from, to CircuitState) {
+	if from != to && b.settings.OnStateChange != nil {
+		b.settings.OnStateChange(b.settings.Name, from, to)
+	}
+}
+
+func isCircuitFailure(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	return resp.StatusCode >= http.StatusInternalServerError
+}
+
+// CircuitBreakerGroup keeps a circuit breaker per host, created on first use, so the clients
+// sharing the group trip together when a host is down
+type CircuitBreakerGroup struct {
+	settings CircuitBreakerSettings
+
+	mu       sync.Mutex
+	breakers map[string]*CircuitBreaker
+}
+
+// NewCircuitBreakerGroup creates an empty group, the breakers are named after their host
+func NewCircuitBreakerGroup(settings CircuitBreakerSettings) *CircuitBreakerGroup {
+	return &CircuitBreakerGroup{
+		settings: settings,
+		breakers: map[string]*CircuitBreaker{},
+	}
+}
+
+// Breaker returns the breaker of the host, creating it on first use
+func (g *CircuitBreakerGroup) Breaker(host string) *CircuitBreaker {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	breaker, ok := g.breakers[host]
+	if !ok {
+		settings := g.settings
+		settings.Name = host
+		breaker = NewCircuitBreaker(settings)
+		g.breakers[host] = breaker
+	}
+
+	return breaker
+}
+
+// Middleware sends each request through the breaker of its host
+func (g *CircuitBreakerGroup) Middleware() Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			return g.Breaker(req.URL.Host).Middleware()(next).Do(req)
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package rd_station_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rd_station "github.com/verbeux-ai/rd-station-go"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	var status atomic.Int32
+	status.Store(http.StatusBadGateway)
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(int(status.Load()))
+		_, _ = w.Write([]byte(`{"id":"123"}`))
+	}))
+	defer server.Close()
+
+	var changes []string
+	breaker := rd_station.NewCircuitBreaker(rd_station.CircuitBreakerSettings{
+		Name:             "crm",
+		FailureThreshold: 3,
+		OpenTimeout:      50 * time.Millisecond,
+		OnStateChange: func(name string, from, to rd_station.CircuitState) {
+			changes = append(changes, name+":"+from.String()+"->"+to.String())
+		},
+	})
+
+	client := rd_station.NewClient(
+		rd_station.WithBaseUrl(server.URL),
+		rd_station.WithToken("secret"),
+		rd_station.WithCircuitBreaker(breaker),
+	)
+	ctx := context.Background()
+
+	for range 3 {
+		_, err := client.GetDeal(ctx, "123")
+		assert.ErrorIs(t, err, rd_station.ErrApiReturnedError)
+	}
+	assert.Equal(t, rd_station.CircuitOpen, breaker.State())
+
+	_, err := client.GetDeal(ctx, "123")
+	assert.ErrorIs(t, err, rd_station.ErrCircuitOpen)
+	assert.EqualValues(t, 3, calls.Load())
+
+	// the probe fails and the circuit opens again
+	time.Sleep(60 * time.Millisecond)
+	assert.Equal(t, rd_station.CircuitHalfOpen, breaker.State())
+	_, err = client.GetDeal(ctx, "123")
+	assert.ErrorIs(t, err, rd_station.ErrApiReturnedError)
+	assert.Equal(t, rd_station.CircuitOpen, breaker.State())
+
+	// the probe succeeds and the circuit closes
+	status.Store(http.StatusOK)
+	time.Sleep(60 * time.Millisecond)
+	_, err = client.GetDeal(ctx, "123")
+	require.NoError(t, err)
+	assert.Equal(t, rd_station.CircuitClosed, breaker.State())
+
+	assert.Equal(t, []string{
+		"crm:closed->open",
+		"crm:open->half-open",
+		"crm:half-open->open",
+		"crm:open->half-open",
+		"crm:half-open->closed",
+	}, changes)
+}
+
+func TestCircuitBreakerIgnoresCanceledRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	breaker := rd_station.NewCircuitBreaker(rd_station.CircuitBreakerSettings{FailureThreshold: 1})
+	client := rd_station.NewClient(
+		rd_station.WithBaseUrl(server.URL),
+		rd_station.WithCircuitBreaker(breaker),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		cancel()
+	}()
+
+	_, err := client.GetDeal(ctx, "123")
+	assert.ErrorIs(t, err, rd_station.ErrRequestFailed)
+	assert.Equal(t, rd_station.CircuitClosed, breaker.State())
+}
+
+func TestCircuitBreakerCanceledRequestsAreNeutral(t *testing.T) {
+	breaker := rd_station.NewCircuitBreaker(rd_station.CircuitBreakerSettings{
+		FailureThreshold: 2,
+		OpenTimeout:      20 * time.Millisecond,
+	})
+
+	var outcome atomic.Value
+	doer := breaker.Middleware()(rd_station.DoerFunc(func(req *http.Request) (*http.Response, error) {
+		switch outcome.Load() {
+		case "canceled":
+			return nil, context.Canceled
+		case "panic":
+			panic("boom")
+		}
+		return &http.Response{StatusCode: http.StatusBadGateway, Body: http.NoBody}, nil
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/deals", nil)
+
+	// a canceled request between two failures neither resets nor increments the count
+	outcome.Store("failure")
+	_, _ = doer.Do(req)
+	outcome.Store("canceled")
+	_, _ = doer.Do(req)
+	assert.Equal(t, rd_station.CircuitClosed, breaker.State())
+	outcome.Store("failure")
+	_, _ = doer.Do(req)
+	assert.Equal(t, rd_station.CircuitOpen, breaker.State())
+
+	// a canceled probe keeps the circuit half-open and lets the next probe through
+	time.Sleep(30 * time.Millisecond)
+	outcome.Store("canceled")
+	_, err := doer.Do(req)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, rd_station.CircuitHalfOpen, breaker.State())
+
+	// a panicking probe releases the probe too
+	outcome.Store("panic")
+	assert.Panics(t, func() { _, _ = doer.Do(req) })
+	assert.Equal(t, rd_station.CircuitHalfOpen, breaker.State())
+
+	outcome.Store("failure")
+	_, err = doer.Do(req)
+	require.NoError(t, err)
+	assert.Equal(t, rd_station.CircuitOpen, breaker.State())
+}
+
+func TestCircuitBreakerIgnoresStaleOutcomes(t *testing.T) {
+	breaker := rd_station.NewCircuitBreaker(rd_station.CircuitBreakerSettings{
+		FailureThreshold: 1,
+		OpenTimeout:      20 * time.Millisecond,
+	})
+
+	slow := make(chan int)
+	doer := breaker.Middleware()(rd_station.DoerFunc(func(req *http.Request) (*http.Response, error) {
+		status := http.StatusBadGateway
+		if req.URL.Path == "/slow" {
+			status = <-slow
+		}
+		return &http.Response{StatusCode: status, Body: http.NoBody}, nil
+	}))
+
+	finished := make(chan struct{})
+	startSlow := func() {
+		go func() {
+			_, _ = doer.Do(httptest.NewRequest(http.MethodGet, "/slow", nil))
+			finished <- struct{}{}
+		}()
+	}
+
+	// two slow requests start while closed, then a failure opens the circuit
+	startSlow()
+	startSlow()
+	time.Sleep(10 * time.Millisecond)
+	_, _ = doer.Do(httptest.NewRequest(http.MethodGet, "/fail", nil))
+	assert.Equal(t, rd_station.CircuitOpen, breaker.State())
+
+	// they finish once the circuit is half-open, before any probe ran
+	time.Sleep(30 * time.Millisecond)
+	slow <- http.StatusOK
+	<-finished
+	slow <- http.StatusBadGateway
+	<-finished
+	assert.Equal(t, rd_station.CircuitHalfOpen, breaker.State())
+
+	// the probe is still let through and decides
+	resp, err := doer.Do(httptest.NewRequest(http.MethodGet, "/fail", nil))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	assert.Equal(t, rd_station.CircuitOpen, breaker.State())
+}
+
+func TestCircuitBreakerGroup(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+
+	group := rd_station.NewCircuitBreakerGroup(rd_station.CircuitBreakerSettings{FailureThreshold: 1, OpenTimeout: time.Minute})
+	first := rd_station.NewClient(rd_station.WithBaseUrl(down.URL), rd_station.WithCircuitBreakerGroup(group))
+	second := rd_station.NewClient(rd_station.WithBaseUrl(down.URL), rd_station.WithCircuitBreakerGroup(group))
+
+	_, err := first.GetDeal(context.Background(), "123")
+	assert.ErrorIs(t, err, rd_station.ErrApiReturnedError)
+
+	// the clients share the breaker of the host
+	_, err = second.GetDeal(context.Background(), "123")
+	assert.ErrorIs(t, err, rd_station.ErrCircuitOpen)
+
+	host, err := url.Parse(down.URL)
+	require.NoError(t, err)
+	assert.Equal(t, rd_station.CircuitOpen, group.Breaker(host.Host).State())
+	assert.Equal(t, rd_station.CircuitClosed, group.Breaker("crm.rdstation.com").State())
+}
@@ -1,13 +1,35 @@
 package rd_station
 
 import (
+	"log/slog"
 	"net/http"
 )
 
 type Client struct {
-	token      string
-	baseUrl    string
-	httpClient *http.Client
+	// The services send the requests of each resource. They may be replaced, e.g. by the mocks
+	// of the rdmock package, to stub the client methods delegating to them.
+	Contacts      ContactsService
+	Deals         DealsService
+	Pipelines     PipelinesService
+	Users         UsersService
+	Activities    ActivitiesService
+	Organizations OrganizationsService
+	CustomFields  CustomFieldsService
+
+	authenticator Authenticator
+	baseUrl       string
+	httpClient    *http.Client
+
+	retryPolicy     RetryPolicy
+	rateLimiter     RateLimiter
+	circuitBreaker  Middleware
+	cache           *Cache
+	middlewares     []Middleware
+	logger          *slog.Logger
+	logOptions      LogOptions
+	doer            Doer
+	phoneNormalizer *PhoneNormalizer
+	skipValidation  bool
 }
 
 // Option is a function that configures a client
@@ -24,13 +46,23 @@ func NewClient(opts ...Option) *Client {
 		opt(c)
 	}
 
+	c.doer = c.buildDoer()
+	c.initServices()
+
 	return c
 }
 
-// WithToken sets the token of the client
+// WithToken sets the token of the client, sent in the query string as required by the CRM API
 func WithToken(token string) Option {
 	return func(c *Client) {
-		c.token = token
+		c.authenticator = QueryTokenAuthenticator(token)
+	}
+}
+
+// WithAuthenticator sets how the client adds credentials to the requests
+func WithAuthenticator(authenticator Authenticator) Option {
+	return func(c *Client) {
+		c.authenticator = authenticator
 	}
 }
 
@@ -47,3 +79,17 @@ func WithHttpClient(httpClient *http.Client) Option {
 		c.httpClient = httpClient
 	}
 }
+
+// WithRetryPolicy sets the policy used to retry failed requests
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retryPolicy = policy
+	}
+}
+
+// WithPhoneNormalizer normalizes the phones sent on contact creation, update and filtering
+func WithPhoneNormalizer(normalizer PhoneNormalizer) Option {
+	return func(c *Client) {
+		c.phoneNormalizer = &normalizer
+	}
+}
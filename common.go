@@ -4,17 +4,97 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// Response is the result of an API call along with the metadata of its response, see Call
 type Response[T any] struct {
-	Data T `json:"data"`
+	Data     T                `json:"data"`
+	Metadata ResponseMetadata `json:"-"`
 }
 
-func (s *Client) request(ctx context.Context, reqBody any, method, endpoint string) (*http.Response, error) {
+// do sends a request to the endpoint and decodes the response into T. The payload is validated
+// first when it's a Validator, then sent as the query string of GET requests and as the JSON
+// body of the others.
+func do[T any](ctx context.Context, s *Client, e endpoint, payload any, pathParams ...string) (*T, error) {
+	if err := s.validate(ctx, e, payload); err != nil {
+		return nil, err
+	}
+
+	var reqBody any
+	var query url.Values
+	if e.method == http.MethodGet {
+		if payload != nil {
+			values, err := EncodeQuery(payload)
+			if err != nil {
+				return nil, fmt.Errorf("error creating query string from filter: %w", err)
+			}
+			query = values
+		}
+	} else {
+		reqBody = payload
+	}
+
+	resp, err := s.request(ctx, e, reqBody, query, pathParams...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error making request to %s: %w", ErrRequestFailed, e.action, err)
+	}
+	defer resp.Body.Close()
+
+	statuses := e.statuses
+	if len(statuses) == 0 {
+		statuses = []int{http.StatusOK}
+	}
+
+	if !slices.Contains(statuses, resp.StatusCode) {
+		bodyBytes, readErr := io.ReadAll(resp.Body)
+		if readErr != nil {
+			return nil, fmt.Errorf("%w: failed to %s (status: %d), read response body error: %w", ErrReadResponseBody, e.action, resp.StatusCode, readErr)
+		}
+		bodyErr := errors.New(string(bodyBytes))
+		if resp.StatusCode == http.StatusNotFound {
+			bodyErr = fmt.Errorf("%w: %w", ErrNotFound, bodyErr)
+		}
+		return nil, fmt.Errorf("%w: failed to %s (status: %d): %w", ErrApiReturnedError, e.action, resp.StatusCode, bodyErr)
+	}
+
+	var responsePayload T
+	if resp.StatusCode == http.StatusNoContent {
+		return &responsePayload, nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&responsePayload); err != nil {
+		return nil, fmt.Errorf("%w: error decoding %s response: %w", ErrDecodeResponse, e.action, err)
+	}
+
+	return &responsePayload, nil
+}
+
+// validate checks the payload when it's a Validator, unless the client or the call skips it
+func (s *Client) validate(ctx context.Context, e endpoint, payload any) error {
+	validator, ok := payload.(Validator)
+	if !ok || s.skipValidation || callConfigFromContext(ctx).skipValidation {
+		return nil
+	}
+
+	if err := validator.Validate(); err != nil {
+		return fmt.Errorf("error validating %s request: %w", e.action, err)
+	}
+
+	return nil
+}
+
+// request sends a request to the endpoint, replacing the "%s" verbs of its path by the
+// pathParams, escaped unless the endpoint sends them raw
+func (s *Client) request(ctx context.Context, e endpoint, reqBody any, query url.Values, pathParams ...string) (*http.Response, error) {
 	var bodyReader io.Reader
 	if reqBody != nil {
 		marshalledBody, err := json.Marshal(reqBody)
@@ -24,21 +104,50 @@ func (s *Client) request(ctx context.Context, reqBody any, method, endpoint stri
 		bodyReader = bytes.NewReader(marshalledBody)
 	}
 
-	url := fmt.Sprintf("%s/%s", s.baseUrl, endpoint)
+	path := e.path
+	if len(pathParams) > 0 {
+		params := make([]any, len(pathParams))
+		for i, param := range pathParams {
+			params[i] = url.PathEscape(param)
+		}
+		path = fmt.Sprintf(e.path, params...)
+	}
 
-	separator := "?"
-	if strings.Contains(url, "?") {
-		separator = "&"
+	fullPath := path
+	if len(query) > 0 {
+		fullPath += "?" + query.Encode()
 	}
 
-	url = fmt.Sprintf("%s%stoken=%s", url, separator, s.token)
+	info := RequestInfo{
+		Operation:        e.operation,
+		Method:           e.method,
+		Endpoint:         path,
+		EndpointTemplate: strings.ReplaceAll(e.path, "%s", "{id}"),
+	}
+	info.Page, _ = strconv.Atoi(query.Get("page"))
 
-	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+	stats := &RequestStats{}
+	ctx = withRequestInfo(ctx, info)
+	ctx = withRequestStats(ctx, stats)
+
+	req, err := http.NewRequestWithContext(ctx, e.method, fmt.Sprintf("%s/%s", s.baseUrl, fullPath), bodyReader)
 	if err != nil {
 		return nil, err
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 
-	return s.httpClient.Do(req)
+	start := time.Now()
+	resp, err := s.doer.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if metadata := callConfigFromContext(ctx).metadata; metadata != nil {
+		if err := captureMetadata(metadata, resp, stats, time.Since(start)); err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
 }
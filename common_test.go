@@ -0,0 +1,53 @@
+package rd_station_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rd_station "github.com/verbeux-ai/rd-station-go"
+)
+
+func TestRequestPipeline(t *testing.T) {
+	var paths, queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.EscapedPath())
+		queries = append(queries, r.URL.RawQuery)
+		switch {
+		case r.Method == http.MethodPost || r.Method == http.MethodPut:
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"1"}`))
+		case r.URL.Path == "/api/v1/deals/broken":
+			_, _ = w.Write([]byte(`{`))
+		default:
+			_, _ = w.Write([]byte(`{"id":"1","deals":[]}`))
+		}
+	}))
+	defer server.Close()
+
+	client := rd_station.NewClient(rd_station.WithBaseUrl(server.URL))
+	ctx := context.Background()
+
+	created, err := client.CreateDeal(ctx, rd_station.CreateDealRequest{Deal: rd_station.CreateDealData{Name: "Website"}})
+	require.NoError(t, err, "create accepts 201")
+	assert.Equal(t, "1", created.ID)
+
+	_, err = client.UpdateDeal(ctx, "1", rd_station.UpdateDealRequest{})
+	assert.ErrorIs(t, err, rd_station.ErrApiReturnedError, "update only accepts 200")
+	assert.ErrorContains(t, err, "failed to update deal (status: 201)")
+
+	_, err = client.GetDeal(ctx, "a/b")
+	require.NoError(t, err)
+	assert.Equal(t, "/api/v1/deals/a%2Fb", paths[len(paths)-1], "path params are escaped")
+
+	_, err = client.ListDealsFilter(ctx, rd_station.ListDealsFilterRequest{Limit: "5", Page: "2"})
+	require.NoError(t, err)
+	assert.Equal(t, "limit=5&page=2", queries[len(queries)-1])
+
+	_, err = client.GetDeal(ctx, "broken")
+	assert.ErrorIs(t, err, rd_station.ErrDecodeResponse)
+	assert.ErrorContains(t, err, "error decoding get deal response")
+}
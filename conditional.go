@@ -0,0 +1,237 @@
+package rd_station
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrConflict = errors.New("record was changed since the expected version")
+)
+
+const defaultConditionalAttempts = 3
+
+// DealMergeFunc rebuilds a deal update on top of the latest version after a conflict. Return
+// ErrConflict to give up.
+type DealMergeFunc func(ctx context.Context, latest *GetDealResponse, update UpdateDealRequest) (UpdateDealRequest, error)
+
+// ContactMergeFunc rebuilds a contact update on top of the latest version after a conflict.
+// Return ErrConflict to give up.
+type ContactMergeFunc func(ctx context.Context, latest *Contact, update UpdateContactRequest) (UpdateContactRequest, error)
+
+type conditionalConfig struct {
+	fingerprint  bool
+	dealMerge    DealMergeFunc
+	contactMerge ContactMergeFunc
+	maxAttempts  int
+	callOptions  []CallOption
+}
+
+// ConditionalOption configures UpdateDealIfUnchanged and UpdateContactIfUnchanged
+type ConditionalOption func(*conditionalConfig)
+
+// WithFingerprint compares the expected version with the fingerprint of the record, from
+// DealFingerprint or ContactFingerprint, instead of its updated_at. Changes to fields out
+// of the fingerprint, such as new interactions, then don't cause conflicts.
+func WithFingerprint() ConditionalOption {
+	return func(c *conditionalConfig) {
+		c.fingerprint = true
+	}
+}
+
+// WithDealMerge retries deal updates after conflicts with the update returned by merge
+func WithDealMerge(merge DealMergeFunc) ConditionalOption {
+	return func(c *conditionalConfig) {
+		c.dealMerge = merge
+	}
+}
+
+// WithContactMerge retries contact updates after conflicts with the update returned by merge
+func WithContactMerge(merge ContactMergeFunc) ConditionalOption {
+	return func(c *conditionalConfig) {
+		c.contactMerge = merge
+	}
+}
+
+// WithMergeAttempts sets the total number of attempts when merging, 3 by default
+func WithMergeAttempts(attempts int) ConditionalOption {
+	return func(c *conditionalConfig) {
+		c.maxAttempts = attempts
+	}
+}
+
+// WithUpdateCallOptions applies the call options to the reads and the update, e.g.:
+// CaptureMetadata, which is filled with the last request, or SkipValidation
+func WithUpdateCallOptions(opts ...CallOption) ConditionalOption {
+	return func(c *conditionalConfig) {
+		c.callOptions = append(c.callOptions, opts...)
+	}
+}
+
+func newConditionalConfig(opts []ConditionalOption) conditionalConfig {
+	c := conditionalConfig{maxAttempts: defaultConditionalAttempts}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return c
+}
+
+// UpdateDealIfUnchanged reads the deal again and only sends the update when it's still at
+// the expected version, its updated_at by default, returning ErrConflict otherwise. The API
+// has no conditional writes, so this narrows the window for lost updates without closing it.
+func (s *Client) UpdateDealIfUnchanged(ctx context.Context, dealID, expectedVersion string, deal UpdateDealRequest, opts ...ConditionalOption) (*UpdateDealResponse, error) {
+	config := newConditionalConfig(opts)
+	ctx = withCallOptions(ctx, config.callOptions)
+
+	for attempt := 1; ; attempt++ {
+		latest, err := s.GetDeal(ctx, dealID)
+		if err != nil {
+			return nil, err
+		}
+
+		version := latest.UpdatedAt
+		if config.fingerprint {
+			version = DealFingerprint(latest)
+		}
+
+		if version == expectedVersion {
+			return s.UpdateDeal(ctx, dealID, deal)
+		}
+
+		if config.dealMerge == nil || attempt >= config.maxAttempts {
+			return nil, fmt.Errorf("%w: deal %s is at version %s, expected %s", ErrConflict, dealID, version, expectedVersion)
+		}
+
+		if deal, err = config.dealMerge(ctx, latest, deal); err != nil {
+			return nil, err
+		}
+		expectedVersion = version
+	}
+}
+
+// UpdateContactIfUnchanged reads the contact again and only sends the update when it's still
+// at the expected version, its updated_at by default, returning ErrConflict otherwise. The
+// API has no conditional writes, so this narrows the window for lost updates without closing it.
+func (s *Client) UpdateContactIfUnchanged(ctx context.Context, contactID, expectedVersion string, contact UpdateContactRequest, opts ...ConditionalOption) (*UpdateContactResponse, error) {
+	config := newConditionalConfig(opts)
+	ctx = withCallOptions(ctx, config.callOptions)
+
+	for attempt := 1; ; attempt++ {
+		latest, err := s.GetContact(ctx, contactID)
+		if err != nil {
+			return nil, err
+		}
+
+		version := latest.UpdatedAt
+		if config.fingerprint {
+			version = ContactFingerprint(latest)
+		}
+
+		if version == expectedVersion {
+			return s.UpdateContact(ctx, contactID, contact)
+		}
+
+		if config.contactMerge == nil || attempt >= config.maxAttempts {
+			return nil, fmt.Errorf("%w: contact %s is at version %s, expected %s", ErrConflict, contactID, version, expectedVersion)
+		}
+
+		if contact, err = config.contactMerge(ctx, latest, contact); err != nil {
+			return nil, err
+		}
+		expectedVersion = version
+	}
+}
+
+// DealFingerprint hashes the fields of the deal that updates write: name, stage, owner,
+// status, prediction date, rating, source and custom fields
+func DealFingerprint(deal *GetDealResponse) string {
+	fields := struct {
+		Name             string                    `json:"name"`
+		DealStage        *DealStageResponse        `json:"deal_stage"`
+		User             *UserResponse             `json:"user"`
+		Win              *string                   `json:"win"`
+		Hold             *string                   `json:"hold"`
+		PredictionDate   *string                   `json:"prediction_date"`
+		Rating           *float64                  `json:"rating"`
+		DealSource       *DealSourceResponse       `json:"deal_source"`
+		DealLostReasonID *string                   `json:"deal_lost_reason_id"`
+		DealCustomFields []DealCustomFieldResponse `json:"deal_custom_fields"`
+	}{
+		Name:             deal.Name,
+		DealStage:        deal.DealStage,
+		User:             deal.User,
+		Win:              deal.Win,
+		Hold:             deal.Hold,
+		PredictionDate:   deal.PredictionDate,
+		Rating:           deal.Rating,
+		DealSource:       deal.DealSource,
+		DealLostReasonID: deal.DealLostReasonID,
+		DealCustomFields: deal.DealCustomFields,
+	}
+
+	return fingerprint(fields)
+}
+
+// ContactFingerprint hashes the fields of the contact that updates write: name, title,
+// birthday, emails, phones, social profiles, organization, legal bases, deals and custom
+// fields. The ids and timestamps the API keeps on the nested records are left out.
+func ContactFingerprint(contact *Contact) string {
+	type phone struct {
+		Phone    string `json:"phone"`
+		Type     string `json:"type"`
+		WhatsApp bool   `json:"whatsapp"`
+	}
+	type customField struct {
+		CustomFieldID string `json:"custom_field_id"`
+		Value         string `json:"value"`
+	}
+
+	fields := struct {
+		Name                string        `json:"name"`
+		Title               *string       `json:"title"`
+		Birthday            BirthdayData  `json:"birthday"`
+		Emails              []string      `json:"emails"`
+		Phones              []phone       `json:"phones"`
+		Facebook            *string       `json:"facebook"`
+		LinkedIn            *string       `json:"linkedin"`
+		Skype               *string       `json:"skype"`
+		OrganizationID      *string       `json:"organization_id"`
+		LegalBases          []LegalBasis  `json:"legal_bases"`
+		DealIDs             []string      `json:"deal_ids"`
+		ContactCustomFields []customField `json:"contact_custom_fields"`
+	}{
+		Name:           contact.Name,
+		Title:          contact.Title,
+		Birthday:       BirthdayData{Day: contact.Birthday.Day, Month: contact.Birthday.Month, Year: contact.Birthday.Year},
+		Facebook:       contact.Facebook,
+		LinkedIn:       contact.LinkedIn,
+		Skype:          contact.Skype,
+		OrganizationID: contact.OrganizationID,
+		LegalBases:     contact.LegalBases,
+		DealIDs:        contactDealIDs(*contact),
+	}
+	for _, email := range contact.Emails {
+		fields.Emails = append(fields.Emails, email.Email)
+	}
+	for _, p := range contact.Phones {
+		fields.Phones = append(fields.Phones, phone{Phone: p.Phone, Type: p.Type, WhatsApp: p.WhatsApp})
+	}
+	for _, field := range contact.ContactCustomFields {
+		fields.ContactCustomFields = append(fields.ContactCustomFields, customField{CustomFieldID: field.CustomFieldID, Value: field.Value})
+	}
+
+	return fingerprint(fields)
+}
+
+func fingerprint(value any) string {
+	// the fields are plain data, so encoding can't fail
+	data, _ := json.Marshal(value)
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:16])
+}
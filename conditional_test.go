@@ -0,0 +1,160 @@
+package rd_station_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rd_station "github.com/verbeux-ai/rd-station-go"
+)
+
+// dealServer serves a single deal and applies the stage of the updates it receives
+type dealServer struct {
+	mu        sync.Mutex
+	updatedAt string
+	stageID   string
+	userID    string
+	updates   int
+}
+
+func (d *dealServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if r.Method == http.MethodPut {
+		var update struct {
+			Deal struct {
+				DealStageID string `json:"deal_stage_id"`
+				UserID      string `json:"user_id"`
+			} `json:"deal"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&update)
+		if update.Deal.DealStageID != "" {
+			d.stageID = update.Deal.DealStageID
+		}
+		if update.Deal.UserID != "" {
+			d.userID = update.Deal.UserID
+		}
+		d.updates++
+		d.updatedAt = "2024-01-02T00:00:00Z"
+	}
+
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"id":         "123",
+		"updated_at": d.updatedAt,
+		"deal_stage": map[string]any{"id": d.stageID},
+		"user":       map[string]any{"id": d.userID},
+	})
+}
+
+func TestUpdateDealIfUnchanged(t *testing.T) {
+	deals := &dealServer{updatedAt: "2024-01-01T00:00:00Z", stageID: "lead", userID: "ana"}
+	server := httptest.NewServer(deals)
+	defer server.Close()
+
+	client := rd_station.NewClient(rd_station.WithBaseUrl(server.URL))
+	ctx := context.Background()
+	update := rd_station.UpdateDealRequest{Deal: rd_station.UpdateDealRequestData{DealStageID: rd_station.Set("proposal")}}
+
+	_, err := client.UpdateDealIfUnchanged(ctx, "123", "2023-12-31T00:00:00Z", update)
+	assert.ErrorIs(t, err, rd_station.ErrConflict)
+	assert.Zero(t, deals.updates)
+
+	updated, err := client.UpdateDealIfUnchanged(ctx, "123", "2024-01-01T00:00:00Z", update)
+	require.NoError(t, err)
+	assert.Equal(t, "2024-01-02T00:00:00Z", updated.UpdatedAt)
+	assert.Equal(t, 1, deals.updates)
+}
+
+func TestUpdateDealIfUnchangedMerge(t *testing.T) {
+	deals := &dealServer{updatedAt: "2024-01-01T00:00:00Z", stageID: "lead", userID: "bruno"}
+	server := httptest.NewServer(deals)
+	defer server.Close()
+
+	client := rd_station.NewClient(rd_station.WithBaseUrl(server.URL))
+	ctx := context.Background()
+
+	// the caller read the deal while it was still assigned to ana
+	update := rd_station.UpdateDealRequest{Deal: rd_station.UpdateDealRequestData{
+		DealStageID: rd_station.Set("proposal"),
+		UserID:      rd_station.Set("ana"),
+	}}
+
+	var merged []string
+	_, err := client.UpdateDealIfUnchanged(ctx, "123", "2023-12-31T00:00:00Z", update,
+		rd_station.WithDealMerge(func(ctx context.Context, latest *rd_station.GetDealResponse, update rd_station.UpdateDealRequest) (rd_station.UpdateDealRequest, error) {
+			merged = append(merged, latest.User.ID)
+			// keep the owner set by the other service
+			update.Deal.UserID = rd_station.Optional[string]{}
+			return update, nil
+		}),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bruno"}, merged)
+	assert.Equal(t, "proposal", deals.stageID)
+	assert.Equal(t, "bruno", deals.userID)
+}
+
+func TestUpdateDealIfUnchangedFingerprint(t *testing.T) {
+	deals := &dealServer{updatedAt: "2024-01-01T00:00:00Z", stageID: "lead", userID: "ana"}
+	server := httptest.NewServer(deals)
+	defer server.Close()
+
+	client := rd_station.NewClient(rd_station.WithBaseUrl(server.URL))
+	ctx := context.Background()
+
+	deal, err := client.GetDeal(ctx, "123")
+	require.NoError(t, err)
+	version := rd_station.DealFingerprint(deal)
+
+	// a change that doesn't touch the fingerprinted fields
+	deals.updatedAt = "2024-01-01T12:00:00Z"
+
+	update := rd_station.UpdateDealRequest{Deal: rd_station.UpdateDealRequestData{DealStageID: rd_station.Set("proposal")}}
+	_, err = client.UpdateDealIfUnchanged(ctx, "123", version, update, rd_station.WithFingerprint())
+	require.NoError(t, err)
+
+	_, err = client.UpdateDealIfUnchanged(ctx, "123", version, update, rd_station.WithFingerprint())
+	assert.ErrorIs(t, err, rd_station.ErrConflict)
+}
+
+func TestContactFingerprint(t *testing.T) {
+	contact := &rd_station.Contact{
+		Name:      "Ana",
+		UpdatedAt: "2024-01-01T00:00:00Z",
+		Emails:    []rd_station.Email{{ID: "1", Email: "ana@example.com", UpdatedAt: "2024-01-01T00:00:00Z"}},
+		Phones:    []rd_station.Phone{{Phone: "+5511987654321", Type: "cellphone"}},
+	}
+	version := rd_station.ContactFingerprint(contact)
+
+	// fields updates can't write don't change the fingerprint
+	contact.UpdatedAt = "2024-01-02T00:00:00Z"
+	contact.Notes = "called"
+	contact.Emails[0].UpdatedAt = "2024-01-02T00:00:00Z"
+	contact.Phones[0].WhatsAppURLWeb = "https://web.whatsapp.com/send?phone=5511987654321"
+	assert.Equal(t, version, rd_station.ContactFingerprint(contact))
+
+	contact.Phones[0].Phone = "+5511912345678"
+	assert.NotEqual(t, version, rd_station.ContactFingerprint(contact))
+}
+
+func TestUpdateDealIfUnchangedCallOptions(t *testing.T) {
+	server := httptest.NewServer(&dealServer{updatedAt: "2024-01-01T00:00:00Z"})
+	defer server.Close()
+
+	client := rd_station.NewClient(rd_station.WithBaseUrl(server.URL))
+
+	var metadata rd_station.ResponseMetadata
+	update := rd_station.UpdateDealRequest{Deal: rd_station.UpdateDealRequestData{DealStageID: rd_station.Set("proposal")}}
+	_, err := client.UpdateDealIfUnchanged(context.Background(), "123", "2024-01-01T00:00:00Z", update,
+		rd_station.WithUpdateCallOptions(rd_station.CaptureMetadata(&metadata)),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, metadata.StatusCode)
+	assert.Contains(t, string(metadata.RawBody), "2024-01-02T00:00:00Z")
+}
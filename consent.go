@@ -0,0 +1,164 @@
+package rd_station
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// LegalBasisCategory is the kind of processing a legal basis covers
+type LegalBasisCategory string
+
+const (
+	LegalBasisCategoryCommunications LegalBasisCategory = "communications"
+	LegalBasisCategoryDataProcessing LegalBasisCategory = "data_processing"
+)
+
+// LegalBasisType is the LGPD ground for the processing
+type LegalBasisType string
+
+const (
+	LegalBasisTypeConsent             LegalBasisType = "consent"
+	LegalBasisTypeLegitimateInterest  LegalBasisType = "legitimate_interest"
+	LegalBasisTypePreExistentContract LegalBasisType = "pre_existent_contract"
+	LegalBasisTypeJudicialProcess     LegalBasisType = "judicial_process"
+	LegalBasisTypeVitalInterest       LegalBasisType = "vital_interest"
+	LegalBasisTypePublicInterest      LegalBasisType = "public_interest"
+)
+
+// LegalBasisStatus tells whether the contact granted or declined the processing
+type LegalBasisStatus string
+
+const (
+	LegalBasisStatusGranted  LegalBasisStatus = "granted"
+	LegalBasisStatusDeclined LegalBasisStatus = "declined"
+)
+
+var (
+	legalBasisCategories = []LegalBasisCategory{LegalBasisCategoryCommunications, LegalBasisCategoryDataProcessing}
+	legalBasisTypes      = []LegalBasisType{
+		LegalBasisTypeConsent, LegalBasisTypeLegitimateInterest, LegalBasisTypePreExistentContract,
+		LegalBasisTypeJudicialProcess, LegalBasisTypeVitalInterest, LegalBasisTypePublicInterest,
+	}
+	legalBasisStatuses = []LegalBasisStatus{LegalBasisStatusGranted, LegalBasisStatusDeclined}
+)
+
+// IsValid reports whether the category is one of the known ones
+func (c LegalBasisCategory) IsValid() bool {
+	return slices.Contains(legalBasisCategories, c)
+}
+
+// IsValid reports whether the type is one of the known ones
+func (t LegalBasisType) IsValid() bool {
+	return slices.Contains(legalBasisTypes, t)
+}
+
+// IsValid reports whether the status is one of the known ones
+func (s LegalBasisStatus) IsValid() bool {
+	return slices.Contains(legalBasisStatuses, s)
+}
+
+// ConsentState is the legal basis of a contact in each category
+type ConsentState struct {
+	ContactID string
+	// UpdatedAt is the updated_at of the contact when the state was read
+	UpdatedAt string
+	Bases     map[LegalBasisCategory]LegalBasis
+}
+
+// Granted reports whether the contact has a granted legal basis in the category
+func (c ConsentState) Granted(category LegalBasisCategory) bool {
+	return c.Bases[category].Status == LegalBasisStatusGranted
+}
+
+// Declined reports whether the contact declined the processing in the category
+func (c ConsentState) Declined(category LegalBasisCategory) bool {
+	return c.Bases[category].Status == LegalBasisStatusDeclined
+}
+
+// ContactConsentState reads the legal bases of the contact, the last one wins when a category
+// is repeated
+func ContactConsentState(contact *Contact) ConsentState {
+	state := ConsentState{
+		ContactID: contact.ID,
+		UpdatedAt: contact.UpdatedAt,
+		Bases:     map[LegalBasisCategory]LegalBasis{},
+	}
+
+	for _, basis := range contact.LegalBases {
+		state.Bases[basis.Category] = basis
+	}
+
+	return state
+}
+
+// GetConsentState fetches the contact and returns its legal bases
+func (s *Client) GetConsentState(ctx context.Context, contactID string, opts ...CallOption) (*ConsentState, error) {
+	contact, err := s.GetContact(ctx, contactID, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	state := ContactConsentState(contact)
+	return &state, nil
+}
+
+// GrantConsent records that the contact consented to the processing in the category
+func (s *Client) GrantConsent(ctx context.Context, contactID string, category LegalBasisCategory, opts ...CallOption) (*UpdateContactResponse, error) {
+	return s.SetLegalBasis(ctx, contactID, LegalBasis{
+		Category: category,
+		Type:     LegalBasisTypeConsent,
+		Status:   LegalBasisStatusGranted,
+	}, opts...)
+}
+
+// RevokeConsent records that the contact withdrew the consent to the processing in the category
+func (s *Client) RevokeConsent(ctx context.Context, contactID string, category LegalBasisCategory, opts ...CallOption) (*UpdateContactResponse, error) {
+	return s.SetLegalBasis(ctx, contactID, LegalBasis{
+		Category: category,
+		Type:     LegalBasisTypeConsent,
+		Status:   LegalBasisStatusDeclined,
+	}, opts...)
+}
+
+// SetLegalBasis replaces the legal basis of the contact in the category of basis, keeping the
+// other categories as they are, even the ones of unknown values. The legal bases are sent
+// whole, so it reads the contact first and merges again when it changes in the meantime.
+func (s *Client) SetLegalBasis(ctx context.Context, contactID string, basis LegalBasis, opts ...CallOption) (*UpdateContactResponse, error) {
+	ctx = withCallOptions(ctx, opts)
+
+	if contactID == "" {
+		return nil, fmt.Errorf("%w: contact id is required", ErrInvalidArgument)
+	}
+
+	if !callConfigFromContext(ctx).skipValidation {
+		var v validator
+		v.legalBasis("legal_basis", basis)
+		if err := v.err(); err != nil {
+			return nil, err
+		}
+	}
+
+	contact, err := s.GetContact(ctx, contactID)
+	if err != nil {
+		return nil, err
+	}
+
+	merge := func(ctx context.Context, latest *Contact, update UpdateContactRequest) (UpdateContactRequest, error) {
+		update.Contact.LegalBases = Set(replaceLegalBasis(latest.LegalBases, basis))
+		return update, nil
+	}
+
+	// only the new basis is validated, the ones already on the contact are sent back untouched
+	return s.UpdateContactIfUnchanged(ctx, contactID, contact.UpdatedAt, UpdateContactRequest{
+		Contact: UpdateContactData{LegalBases: Set(replaceLegalBasis(contact.LegalBases, basis))},
+	}, WithContactMerge(merge), WithUpdateCallOptions(SkipValidation()))
+}
+
+func replaceLegalBasis(bases []LegalBasis, basis LegalBasis) []LegalBasis {
+	bases = slices.DeleteFunc(slices.Clone(bases), func(current LegalBasis) bool {
+		return current.Category == basis.Category
+	})
+
+	return append(bases, basis)
+}
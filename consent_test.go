@@ -0,0 +1,90 @@
+package rd_station_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rd_station "github.com/verbeux-ai/rd-station-go"
+)
+
+func TestConsentManagement(t *testing.T) {
+	var sent rd_station.UpdateContactRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&sent))
+			_, _ = w.Write([]byte(`{"id":"c1"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"c1","updated_at":"2024-01-01T00:00:00Z","legal_bases":[
+			{"category":"communications","type":"consent","status":"granted"},
+			{"category":"data_processing","type":"legitimate_interest","status":"granted"}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := rd_station.NewClient(rd_station.WithBaseUrl(server.URL))
+	ctx := context.Background()
+
+	state, err := client.GetConsentState(ctx, "c1")
+	require.NoError(t, err)
+	assert.True(t, state.Granted(rd_station.LegalBasisCategoryCommunications))
+	assert.Equal(t, rd_station.LegalBasisTypeLegitimateInterest, state.Bases[rd_station.LegalBasisCategoryDataProcessing].Type)
+
+	_, err = client.RevokeConsent(ctx, "c1", rd_station.LegalBasisCategoryCommunications)
+	require.NoError(t, err)
+
+	bases, ok := sent.Contact.LegalBases.Get()
+	require.True(t, ok)
+	assert.Equal(t, []rd_station.LegalBasis{
+		{Category: rd_station.LegalBasisCategoryDataProcessing, Type: rd_station.LegalBasisTypeLegitimateInterest, Status: rd_station.LegalBasisStatusGranted},
+		{Category: rd_station.LegalBasisCategoryCommunications, Type: rd_station.LegalBasisTypeConsent, Status: rd_station.LegalBasisStatusDeclined},
+	}, bases)
+	assert.False(t, sent.Contact.Name.IsSet(), "only the legal bases are sent")
+
+	_, err = client.SetLegalBasis(ctx, "c1", rd_station.LegalBasis{Category: "marketing", Type: "consent", Status: "granted"})
+	assert.ErrorIs(t, err, rd_station.ErrValidation)
+}
+
+func TestSetLegalBasisMergesConcurrentChanges(t *testing.T) {
+	var gets int
+	var sent rd_station.UpdateContactRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&sent))
+			_, _ = w.Write([]byte(`{"id":"c1"}`))
+			return
+		}
+
+		// the category unknown to the client is kept, the other one is granted in between
+		gets++
+		if gets == 1 {
+			_, _ = w.Write([]byte(`{"id":"c1","updated_at":"v1","legal_bases":[
+				{"category":"profiling","type":"contract","status":"granted"}
+			]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"c1","updated_at":"v2","legal_bases":[
+			{"category":"profiling","type":"contract","status":"granted"},
+			{"category":"data_processing","type":"consent","status":"granted"}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := rd_station.NewClient(rd_station.WithBaseUrl(server.URL))
+
+	_, err := client.GrantConsent(context.Background(), "c1", rd_station.LegalBasisCategoryCommunications)
+	require.NoError(t, err)
+
+	bases, ok := sent.Contact.LegalBases.Get()
+	require.True(t, ok)
+	assert.Equal(t, []rd_station.LegalBasis{
+		{Category: "profiling", Type: "contract", Status: rd_station.LegalBasisStatusGranted},
+		{Category: rd_station.LegalBasisCategoryDataProcessing, Type: rd_station.LegalBasisTypeConsent, Status: rd_station.LegalBasisStatusGranted},
+		{Category: rd_station.LegalBasisCategoryCommunications, Type: rd_station.LegalBasisTypeConsent, Status: rd_station.LegalBasisStatusGranted},
+	}, bases)
+}
@@ -2,11 +2,8 @@ package rd_station
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
-	"net/http"
 )
 
 var (
@@ -14,6 +11,8 @@ var (
 	ErrReadResponseBody = errors.New("failed to read response body")
 	ErrApiReturnedError = errors.New("api returned an error status")
 	ErrDecodeResponse   = errors.New("failed to decode api response")
+	// ErrNotFound is wrapped along with ErrApiReturnedError when the API answers 404
+	ErrNotFound = errors.New("record not found")
 )
 
 type Contact struct {
@@ -60,24 +59,28 @@ type Email struct {
 	UpdatedAt string `json:"updated_at"`
 }
 
+// LegalBasis is the LGPD basis for processing the data of a contact in a category
 type LegalBasis struct {
-	Category string `json:"category"`
-	Status   string `json:"status"`
-	Type     string `json:"type"`
+	Category LegalBasisCategory `json:"category"`
+	Status   LegalBasisStatus   `json:"status"`
+	Type     LegalBasisType     `json:"type"`
 }
 
+// Phone is a contact phone. The fields filled by the API are left out of update payloads
+// when empty, so sending back a phone built from the number alone doesn't clear them.
 type Phone struct {
-	CreatedAt                 string `json:"created_at"`
+	CreatedAt                 string `json:"created_at,omitempty"`
 	Phone                     string `json:"phone"`
 	Type                      string `json:"type"`
-	UpdatedAt                 string `json:"updated_at"`
+	UpdatedAt                 string `json:"updated_at,omitempty"`
 	WhatsApp                  bool   `json:"whatsapp"`
-	WhatsAppFullInternacional string `json:"whatsapp_full_internacional"`
-	WhatsAppURLWeb            string `json:"whatsapp_url_web"`
+	WhatsAppFullInternacional string `json:"whatsapp_full_internacional,omitempty"`
+	WhatsAppURLWeb            string `json:"whatsapp_url_web,omitempty"`
 }
 
 type ListContactsFilterRequest struct {
-	Token     string `form:"token" query:"token"`
+	// Deprecated: the client sends its own token, this field is ignored
+	Token     string `form:"token" query:"-"`
 	Page      string `form:"page,omitempty" query:"page"`
 	Limit     string `form:"limit,omitempty" query:"limit"`
 	Order     string `form:"order,omitempty" query:"order"`
@@ -95,38 +98,26 @@ type ListContactsFilterResponse struct {
 	Total    float64   `json:"total"`
 }
 
-func (s *Client) ListContactsFilter(ctx context.Context, filter ListContactsFilterRequest) (*ListContactsFilterResponse, error) {
-	queryString, err := StructToQueryString(filter)
-	if err != nil {
-		return nil, fmt.Errorf("error creating query string from filter: %w", err)
-	}
-
-	fullPath := listContactsEndpoint
-	if queryString != "" {
-		fullPath += "?" + queryString
-	}
+func (s *contactsService) List(ctx context.Context, filter ListContactsFilterRequest, opts ...CallOption) (*ListContactsFilterResponse, error) {
+	ctx = withCallOptions(ctx, opts)
 
-	resp, err := s.request(ctx, nil, http.MethodGet, fullPath)
-	if err != nil {
-		return nil, fmt.Errorf("%w: error making request to list contacts: %w", ErrRequestFailed, err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, readErr := io.ReadAll(resp.Body)
-		if readErr != nil {
-			return nil, fmt.Errorf("%w: failed to list contacts (status: %d), read response body error: %w", ErrReadResponseBody, resp.StatusCode, readErr)
+	if filter.Phone != "" {
+		phone, err := s.client.normalizePhone(filter.Phone)
+		if err != nil {
+			return nil, fmt.Errorf("error normalizing filter phone: %w", err)
 		}
-		bodyErr := errors.New(string(bodyBytes))
-		return nil, fmt.Errorf("%w: failed to list contacts (status: %d): %w", ErrApiReturnedError, resp.StatusCode, bodyErr)
+		filter.Phone = phone
 	}
 
-	var responsePayload ListContactsFilterResponse
-	if err := json.NewDecoder(resp.Body).Decode(&responsePayload); err != nil {
-		return nil, fmt.Errorf("%w: error decoding list contacts response: %w", ErrDecodeResponse, err)
-	}
+	return s.client.listContacts(ctx, filter)
+}
+
+func (s *Client) ListContactsFilter(ctx context.Context, filter ListContactsFilterRequest, opts ...CallOption) (*ListContactsFilterResponse, error) {
+	return s.Contacts.List(ctx, filter, opts...)
+}
 
-	return &responsePayload, nil
+func (s *Client) listContacts(ctx context.Context, filter ListContactsFilterRequest) (*ListContactsFilterResponse, error) {
+	return do[ListContactsFilterResponse](ctx, s, listContactsEndpoint, filter)
 }
 
 type BirthdayData struct {
@@ -200,43 +191,47 @@ type OrganizationResponse struct {
 	URL    string `json:"url"`
 }
 
-func (s *Client) CreateContact(ctx context.Context, contact CreateContactRequest) (*CreateContactResponse, error) {
-	resp, err := s.request(ctx, contact, http.MethodPost, createContactEndpoint)
-	if err != nil {
-		return nil, fmt.Errorf("%w: error making request to create contact: %w", ErrRequestFailed, err)
+func (s *contactsService) Create(ctx context.Context, contact CreateContactRequest, opts ...CallOption) (*CreateContactResponse, error) {
+	ctx = withCallOptions(ctx, opts)
+
+	// validated before normalizing, so an invalid phone is reported as a validation error
+	if err := s.client.validate(ctx, createContactEndpoint, contact); err != nil {
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		bodyBytes, readErr := io.ReadAll(resp.Body)
-		if readErr != nil {
-			return nil, fmt.Errorf("%w: failed to create contact (status: %d), read response body error: %w", ErrReadResponseBody, resp.StatusCode, readErr)
+	if contact.Contact.Phones != nil {
+		phones, err := s.client.normalizePhoneData(*contact.Contact.Phones)
+		if err != nil {
+			return nil, fmt.Errorf("error normalizing contact phones: %w", err)
 		}
-		bodyErr := errors.New(string(bodyBytes))
-		return nil, fmt.Errorf("%w: failed to create contact (status: %d): %w", ErrApiReturnedError, resp.StatusCode, bodyErr)
+		contact.Contact.Phones = &phones
 	}
 
-	var responsePayload CreateContactResponse
-	if err := json.NewDecoder(resp.Body).Decode(&responsePayload); err != nil {
-		return nil, fmt.Errorf("%w: error decoding create contact response: %w", ErrDecodeResponse, err)
-	}
+	return do[CreateContactResponse](ctx, s.client, createContactEndpoint, contact)
+}
 
-	return &responsePayload, nil
+func (s *Client) CreateContact(ctx context.Context, contact CreateContactRequest, opts ...CallOption) (*CreateContactResponse, error) {
+	return s.Contacts.Create(ctx, contact, opts...)
 }
 
+// UpdateContactData holds the fields to change, the unset ones are left untouched
 type UpdateContactData struct {
-	Birthday            *BirthdayData        `json:"birthday,omitempty"`
-	ContactCustomFields []ContactCustomField `json:"contact_custom_fields,omitempty"`
-	DealIDs             []string             `json:"deal_ids,omitempty"`
-	Emails              []EmailData          `json:"emails,omitempty"`
-	Facebook            *string              `json:"facebook,omitempty"`
-	LegalBases          []LegalBasis         `json:"legal_bases,omitempty"`
-	LinkedIn            *string              `json:"linkedin,omitempty"`
-	Name                string               `json:"name,omitempty"`
-	OrganizationID      *string              `json:"organization_id,omitempty"`
-	Phones              []Phone              `json:"phones,omitempty"`
-	Skype               *string              `json:"skype,omitempty"`
-	Title               *string              `json:"title,omitempty"`
+	Birthday            Optional[BirthdayData]         `json:"birthday"`
+	ContactCustomFields Optional[[]ContactCustomField] `json:"contact_custom_fields"`
+	DealIDs             Optional[[]string]             `json:"deal_ids"`
+	Emails              Optional[[]EmailData]          `json:"emails"`
+	Facebook            Optional[string]               `json:"facebook"`
+	LegalBases          Optional[[]LegalBasis]         `json:"legal_bases"`
+	LinkedIn            Optional[string]               `json:"linkedin"`
+	Name                Optional[string]               `json:"name"`
+	OrganizationID      Optional[string]               `json:"organization_id"`
+	Phones              Optional[[]Phone]              `json:"phones"`
+	Skype               Optional[string]               `json:"skype"`
+	Title               Optional[string]               `json:"title"`
+}
+
+func (d UpdateContactData) MarshalJSON() ([]byte, error) {
+	return marshalPartial(d)
 }
 
 type UpdateContactRequest struct {
@@ -265,26 +260,32 @@ type UpdateContactResponse struct {
 	UpdatedAt           string               `json:"updated_at"`
 }
 
-func (s *Client) UpdateContact(ctx context.Context, contactID string, contact UpdateContactRequest) (*UpdateContactResponse, error) {
-	resp, err := s.request(ctx, contact, http.MethodPut, fmt.Sprintf(updateContactByIDEndpoint, contactID))
-	if err != nil {
-		return nil, fmt.Errorf("%w: error making request to update contact: %w", ErrRequestFailed, err)
+func (s *contactsService) Update(ctx context.Context, contactID string, contact UpdateContactRequest, opts ...CallOption) (*UpdateContactResponse, error) {
+	ctx = withCallOptions(ctx, opts)
+
+	if err := s.client.validate(ctx, updateContactByIDEndpoint, contact); err != nil {
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, readErr := io.ReadAll(resp.Body)
-		if readErr != nil {
-			return nil, fmt.Errorf("%w: failed to update contact (status: %d), read response body error: %w", ErrReadResponseBody, resp.StatusCode, readErr)
+	if phones, ok := contact.Contact.Phones.Get(); ok {
+		normalized, err := s.client.normalizePhones(phones)
+		if err != nil {
+			return nil, fmt.Errorf("error normalizing contact phones: %w", err)
 		}
-		bodyErr := errors.New(string(bodyBytes))
-		return nil, fmt.Errorf("%w: failed to update contact (status: %d): %w", ErrApiReturnedError, resp.StatusCode, bodyErr)
+		contact.Contact.Phones = Set(normalized)
 	}
 
-	var responsePayload UpdateContactResponse
-	if err := json.NewDecoder(resp.Body).Decode(&responsePayload); err != nil {
-		return nil, fmt.Errorf("%w: error decoding update contact response: %w", ErrDecodeResponse, err)
-	}
+	return do[UpdateContactResponse](ctx, s.client, updateContactByIDEndpoint, contact, contactID)
+}
+
+func (s *Client) UpdateContact(ctx context.Context, contactID string, contact UpdateContactRequest, opts ...CallOption) (*UpdateContactResponse, error) {
+	return s.Contacts.Update(ctx, contactID, contact, opts...)
+}
+
+func (s *contactsService) Get(ctx context.Context, contactID string, opts ...CallOption) (*Contact, error) {
+	return do[Contact](withCallOptions(ctx, opts), s.client, getContactByIDEndpoint, nil, contactID)
+}
 
-	return &responsePayload, nil
+func (s *Client) GetContact(ctx context.Context, contactID string, opts ...CallOption) (*Contact, error) {
+	return s.Contacts.Get(ctx, contactID, opts...)
 }
@@ -148,8 +148,8 @@ func TestUpdateContact(t *testing.T) {
 
 	updateContact := rd_station.UpdateContactRequest{
 		Contact: rd_station.UpdateContactData{
-			Name:  newName,
-			Title: &newTitle,
+			Name:  rd_station.Set(newName),
+			Title: rd_station.Set(newTitle),
 		},
 	}
 
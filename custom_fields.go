@@ -0,0 +1,41 @@
+package rd_station
+
+import "context"
+
+// CustomFieldFor is the entity a custom field belongs to
+type CustomFieldFor string
+
+const (
+	CustomFieldForDeal         CustomFieldFor = "deal"
+	CustomFieldForContact      CustomFieldFor = "contact"
+	CustomFieldForOrganization CustomFieldFor = "organization"
+)
+
+type CustomField struct {
+	ID        string         `json:"id"`
+	Label     string         `json:"label"`
+	Type      string         `json:"type"`
+	For       CustomFieldFor `json:"for"`
+	Required  bool           `json:"required"`
+	Unique    bool           `json:"unique"`
+	Opts      []string       `json:"opts"`
+	CreatedAt string         `json:"created_at"`
+	UpdatedAt string         `json:"updated_at"`
+}
+
+type ListCustomFieldsFilterRequest struct {
+	// For filters the custom fields by entity, all of them are listed when empty
+	For CustomFieldFor `form:"option,omitempty" query:"option"`
+}
+
+type ListCustomFieldsResponse struct {
+	CustomFields []CustomField `json:"custom_fields"`
+}
+
+func (s *customFieldsService) List(ctx context.Context, filter ListCustomFieldsFilterRequest, opts ...CallOption) (*ListCustomFieldsResponse, error) {
+	return do[ListCustomFieldsResponse](withCallOptions(ctx, opts), s.client, listCustomFieldsEndpoint, filter)
+}
+
+func (s *Client) ListCustomFields(ctx context.Context, filter ListCustomFieldsFilterRequest, opts ...CallOption) (*ListCustomFieldsResponse, error) {
+	return s.CustomFields.List(ctx, filter, opts...)
+}
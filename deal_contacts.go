@@ -0,0 +1,93 @@
+package rd_station
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+type ListDealContactsFilterRequest struct {
+	Page  string `form:"page,omitempty" query:"page"`
+	Limit string `form:"limit,omitempty" query:"limit"`
+}
+
+type ListDealContactsResponse struct {
+	Contacts []Contact `json:"contacts"`
+	HasMore  bool      `json:"has_more"`
+	Total    int       `json:"total"`
+}
+
+func (r ListDealContactsFilterRequest) Validate() error {
+	var v validator
+	v.page(r.Page, r.Limit)
+
+	return v.err()
+}
+
+func (s *dealsService) ListContacts(ctx context.Context, dealID string, filter ListDealContactsFilterRequest, opts ...CallOption) (*ListDealContactsResponse, error) {
+	return do[ListDealContactsResponse](withCallOptions(ctx, opts), s.client, listDealContactsEndpoint, filter, dealID)
+}
+
+// ListDealContacts lists the contacts associated with the deal
+func (s *Client) ListDealContacts(ctx context.Context, dealID string, filter ListDealContactsFilterRequest, opts ...CallOption) (*ListDealContactsResponse, error) {
+	return s.Deals.ListContacts(ctx, dealID, filter, opts...)
+}
+
+// LinkContactToDeal associates the contact with the deal, keeping its other deals. The API
+// only replaces the whole list of deals of a contact, so the contact is read first and the
+// update retried on top of concurrent changes. Linking an associated contact does nothing.
+func (s *Client) LinkContactToDeal(ctx context.Context, dealID, contactID string, opts ...CallOption) error {
+	return s.changeContactDeals(ctx, dealID, contactID, opts, func(dealIDs []string) []string {
+		if slices.Contains(dealIDs, dealID) {
+			return nil
+		}
+		return append(dealIDs, dealID)
+	})
+}
+
+// UnlinkContactFromDeal removes the association of the contact with the deal, keeping its
+// other deals, as LinkContactToDeal does. Unlinking a contact not associated does nothing.
+func (s *Client) UnlinkContactFromDeal(ctx context.Context, dealID, contactID string, opts ...CallOption) error {
+	return s.changeContactDeals(ctx, dealID, contactID, opts, func(dealIDs []string) []string {
+		if !slices.Contains(dealIDs, dealID) {
+			return nil
+		}
+		return slices.DeleteFunc(dealIDs, func(id string) bool { return id == dealID })
+	})
+}
+
+// changeContactDeals updates the deals of the contact with the list returned by change, nil
+// when there's nothing to change
+func (s *Client) changeContactDeals(ctx context.Context, dealID, contactID string, opts []CallOption, change func(dealIDs []string) []string) error {
+	ctx = withCallOptions(ctx, opts)
+
+	if dealID == "" || contactID == "" {
+		return fmt.Errorf("%w: deal id and contact id are required", ErrInvalidArgument)
+	}
+
+	contact, err := s.GetContact(ctx, contactID)
+	if err != nil {
+		return err
+	}
+
+	dealIDs := change(contactDealIDs(*contact))
+	if dealIDs == nil {
+		return nil
+	}
+
+	merge := func(ctx context.Context, latest *Contact, update UpdateContactRequest) (UpdateContactRequest, error) {
+		dealIDs := change(contactDealIDs(*latest))
+		if dealIDs == nil {
+			// the latest version already has the change, sending the same list is harmless
+			dealIDs = contactDealIDs(*latest)
+		}
+		update.Contact.DealIDs = Set(dealIDs)
+		return update, nil
+	}
+
+	_, err = s.UpdateContactIfUnchanged(ctx, contactID, contact.UpdatedAt, UpdateContactRequest{
+		Contact: UpdateContactData{DealIDs: Set(dealIDs)},
+	}, WithContactMerge(merge))
+
+	return err
+}
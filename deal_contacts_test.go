@@ -0,0 +1,52 @@
+package rd_station_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rd_station "github.com/verbeux-ai/rd-station-go"
+)
+
+func TestDealContactAssociations(t *testing.T) {
+	var updates [][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v1/deals/d1/contacts":
+			assert.Equal(t, "2", r.URL.Query().Get("page"))
+			_, _ = w.Write([]byte(`{"contacts":[{"id":"c1"}],"has_more":false,"total":1}`))
+		case r.Method == http.MethodPut:
+			var update struct {
+				Contact struct {
+					DealIDs []string `json:"deal_ids"`
+				} `json:"contact"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&update))
+			updates = append(updates, update.Contact.DealIDs)
+			_, _ = w.Write([]byte(`{"id":"c1"}`))
+		default:
+			_, _ = w.Write([]byte(`{"id":"c1","updated_at":"v1","deals":[{"id":"d1"},{"id":"d2"}]}`))
+		}
+	}))
+	defer server.Close()
+
+	client := rd_station.NewClient(rd_station.WithBaseUrl(server.URL))
+	ctx := context.Background()
+
+	contacts, err := client.ListDealContacts(ctx, "d1", rd_station.ListDealContactsFilterRequest{Page: "2"})
+	require.NoError(t, err)
+	assert.Equal(t, "c1", contacts.Contacts[0].ID)
+
+	require.NoError(t, client.LinkContactToDeal(ctx, "d3", "c1"))
+	require.NoError(t, client.LinkContactToDeal(ctx, "d1", "c1"), "linking again does nothing")
+	require.NoError(t, client.UnlinkContactFromDeal(ctx, "d1", "c1"))
+	require.NoError(t, client.UnlinkContactFromDeal(ctx, "d9", "c1"), "unlinking a missing deal does nothing")
+
+	assert.Equal(t, [][]string{{"d1", "d2", "d3"}, {"d2"}}, updates)
+
+	assert.ErrorIs(t, client.LinkContactToDeal(ctx, "", "c1"), rd_station.ErrInvalidArgument)
+}
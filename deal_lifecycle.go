@@ -0,0 +1,173 @@
+package rd_station
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+var (
+	ErrInvalidArgument    = errors.New("invalid argument")
+	ErrStageNotInPipeline = errors.New("deal stage does not belong to the deal pipeline")
+	ErrLostReasonNotFound = errors.New("deal lost reason not found")
+	ErrDealWithoutStage   = errors.New("deal has no stage")
+)
+
+const (
+	dealWinStatusWon        = "true"
+	dealWinStatusLost       = "false"
+	dealHoldStatusPaused    = "true"
+	dealHoldStatusNotPaused = "false"
+
+	maxDealStagesPageLimit = 200
+)
+
+func (s *dealsService) MoveToStage(ctx context.Context, dealID, stageID string, opts ...CallOption) (*UpdateDealResponse, error) {
+	ctx = withCallOptions(ctx, opts)
+
+	if dealID == "" || stageID == "" {
+		return nil, fmt.Errorf("%w: deal id and stage id are required", ErrInvalidArgument)
+	}
+
+	deal, err := s.client.GetDeal(ctx, dealID)
+	if err != nil {
+		return nil, err
+	}
+
+	if deal.DealStage == nil || deal.DealStage.DealPipelineID == "" {
+		return nil, fmt.Errorf("%w: deal %s", ErrDealWithoutStage, dealID)
+	}
+
+	found, err := s.client.pipelineHasStage(ctx, deal.DealStage.DealPipelineID, stageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, fmt.Errorf("%w: stage %s, pipeline %s", ErrStageNotInPipeline, stageID, deal.DealStage.DealPipelineID)
+	}
+
+	return s.client.UpdateDeal(ctx, dealID, UpdateDealRequest{
+		Deal: UpdateDealRequestData{DealStageID: Set(stageID)},
+	})
+}
+
+// MoveDealToStage moves the deal to another stage of the same pipeline
+func (s *Client) MoveDealToStage(ctx context.Context, dealID, stageID string, opts ...CallOption) (*UpdateDealResponse, error) {
+	return s.Deals.MoveToStage(ctx, dealID, stageID, opts...)
+}
+
+func (s *dealsService) MarkWon(ctx context.Context, dealID string, opts ...CallOption) (*UpdateDealResponse, error) {
+	ctx = withCallOptions(ctx, opts)
+
+	if dealID == "" {
+		return nil, fmt.Errorf("%w: deal id is required", ErrInvalidArgument)
+	}
+
+	return s.client.UpdateDeal(ctx, dealID, UpdateDealRequest{
+		Deal: UpdateDealRequestData{Win: Set(dealWinStatusWon)},
+	})
+}
+
+// MarkDealWon closes the deal as won
+func (s *Client) MarkDealWon(ctx context.Context, dealID string, opts ...CallOption) (*UpdateDealResponse, error) {
+	return s.Deals.MarkWon(ctx, dealID, opts...)
+}
+
+func (s *dealsService) MarkLost(ctx context.Context, dealID, reasonID, note string, opts ...CallOption) (*UpdateDealResponse, error) {
+	ctx = withCallOptions(ctx, opts)
+
+	if dealID == "" || reasonID == "" {
+		return nil, fmt.Errorf("%w: deal id and lost reason id are required", ErrInvalidArgument)
+	}
+
+	reasons, err := s.client.ListDealLostReasons(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	found := false
+	for _, reason := range reasons.DealLostReasons {
+		if reason.ID == reasonID {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("%w: %s", ErrLostReasonNotFound, reasonID)
+	}
+
+	data := UpdateDealRequestData{
+		Win:              Set(dealWinStatusLost),
+		DealLostReasonID: Set(reasonID),
+	}
+	if note != "" {
+		data.DealLostNote = Set(note)
+	}
+
+	return s.client.UpdateDeal(ctx, dealID, UpdateDealRequest{Deal: data})
+}
+
+// MarkDealLost closes the deal as lost with one of the account lost reasons and an optional note
+func (s *Client) MarkDealLost(ctx context.Context, dealID, reasonID, note string, opts ...CallOption) (*UpdateDealResponse, error) {
+	return s.Deals.MarkLost(ctx, dealID, reasonID, note, opts...)
+}
+
+func (s *dealsService) Pause(ctx context.Context, dealID string, opts ...CallOption) (*UpdateDealResponse, error) {
+	ctx = withCallOptions(ctx, opts)
+
+	return s.client.setDealHold(ctx, dealID, dealHoldStatusPaused)
+}
+
+// PauseDeal puts the deal on hold
+func (s *Client) PauseDeal(ctx context.Context, dealID string, opts ...CallOption) (*UpdateDealResponse, error) {
+	return s.Deals.Pause(ctx, dealID, opts...)
+}
+
+func (s *dealsService) Resume(ctx context.Context, dealID string, opts ...CallOption) (*UpdateDealResponse, error) {
+	ctx = withCallOptions(ctx, opts)
+
+	return s.client.setDealHold(ctx, dealID, dealHoldStatusNotPaused)
+}
+
+// ResumeDeal removes the deal from hold
+func (s *Client) ResumeDeal(ctx context.Context, dealID string, opts ...CallOption) (*UpdateDealResponse, error) {
+	return s.Deals.Resume(ctx, dealID, opts...)
+}
+
+func (s *Client) setDealHold(ctx context.Context, dealID, hold string) (*UpdateDealResponse, error) {
+	if dealID == "" {
+		return nil, fmt.Errorf("%w: deal id is required", ErrInvalidArgument)
+	}
+
+	return s.UpdateDeal(ctx, dealID, UpdateDealRequest{
+		Deal: UpdateDealRequestData{Hold: Set(hold)},
+	})
+}
+
+func (s *Client) pipelineHasStage(ctx context.Context, pipelineID, stageID string) (bool, error) {
+	stages := Paginate(ctx, func(ctx context.Context, page int) ([]DealStageResponse, bool, error) {
+		response, err := s.ListDealStagesFilter(ctx, ListDealStagesFilterRequest{
+			Page:           strconv.Itoa(page),
+			Limit:          strconv.Itoa(maxDealStagesPageLimit),
+			DealPipelineID: pipelineID,
+		})
+		if err != nil {
+			return nil, false, err
+		}
+		return response.DealStages, response.HasMore, nil
+	})
+
+	for stage, err := range stages {
+		if err != nil {
+			return false, err
+		}
+		if stage.ID == stageID {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -1,40 +1,35 @@
 package rd_station
 
-import (
-	"context"
-	"encoding/json"
-	"errors"
-	"fmt"
-	"io"
-	"net/http"
-)
+import "context"
 
 type Deal struct {
-	ID                   string        `json:"id"`
-	AmountMonthly        float64       `json:"amount_montly"`
-	AmountTotal          float64       `json:"amount_total"`
-	AmountUnique         float64       `json:"amount_unique"`
-	ClosedAt             string        `json:"closed_at"`
-	Deals                []Deal        `json:"deals"`
-	CreatedAt            string        `json:"created_at"`
-	DealCustomFields     []interface{} `json:"deal_custom_fields"`
-	DealProducts         []DealProduct `json:"deal_products"`
-	DealStage            DealStage     `json:"deal_stage"`
-	Hold                 string        `json:"hold"`
-	Interactions         int           `json:"interactions"`
-	LastActivityAt       string        `json:"last_activity_at"`
-	LastActivityContent  string        `json:"last_activity_content"`
-	Markup               string        `json:"markup"`
-	MarkupCreated        string        `json:"markup_created"`
-	MarkupLastActivities string        `json:"markup_last_activities"`
-	Name                 string        `json:"name"`
-	PredictionDate       string        `json:"prediction_date"`
-	Rating               int           `json:"rating"`
-	StopTimeLimit        interface{}   `json:"stop_time_limit"`
-	UpdatedAt            string        `json:"updated_at"`
-	User                 User          `json:"user"`
-	UserChanged          bool          `json:"user_changed"`
-	Win                  string        `json:"win"`
+	ID                   string                     `json:"id"`
+	AmountMonthly        float64                    `json:"amount_montly"`
+	AmountTotal          float64                    `json:"amount_total"`
+	AmountUnique         float64                    `json:"amount_unique"`
+	ClosedAt             string                     `json:"closed_at"`
+	Deals                []Deal                     `json:"deals"`
+	CreatedAt            string                     `json:"created_at"`
+	DealCustomFields     []interface{}              `json:"deal_custom_fields"`
+	DealProducts         []DealProduct              `json:"deal_products"`
+	DealSource           *DealSourceResponse        `json:"deal_source"`
+	DealStage            DealStage                  `json:"deal_stage"`
+	DealStageHistories   []DealStageHistoryResponse `json:"deal_stage_histories"`
+	Hold                 string                     `json:"hold"`
+	Interactions         int                        `json:"interactions"`
+	LastActivityAt       string                     `json:"last_activity_at"`
+	LastActivityContent  string                     `json:"last_activity_content"`
+	Markup               string                     `json:"markup"`
+	MarkupCreated        string                     `json:"markup_created"`
+	MarkupLastActivities string                     `json:"markup_last_activities"`
+	Name                 string                     `json:"name"`
+	PredictionDate       string                     `json:"prediction_date"`
+	Rating               int                        `json:"rating"`
+	StopTimeLimit        interface{}                `json:"stop_time_limit"`
+	UpdatedAt            string                     `json:"updated_at"`
+	User                 User                       `json:"user"`
+	UserChanged          bool                       `json:"user_changed"`
+	Win                  string                     `json:"win"`
 }
 
 type DealProduct struct {
@@ -54,12 +49,13 @@ type DealProduct struct {
 }
 
 type DealStage struct {
-	InternalID string `json:"_id"`
-	CreatedAt  string `json:"created_at"`
-	ID         string `json:"id"`
-	Name       string `json:"name"`
-	Nickname   string `json:"nickname"`
-	UpdatedAt  string `json:"updated_at"`
+	InternalID     string `json:"_id"`
+	CreatedAt      string `json:"created_at"`
+	DealPipelineID string `json:"deal_pipeline_id"`
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	Nickname       string `json:"nickname"`
+	UpdatedAt      string `json:"updated_at"`
 }
 
 type User struct {
@@ -71,7 +67,8 @@ type User struct {
 }
 
 type ListDealsFilterRequest struct {
-	Token     string `form:"token" query:"token"`
+	// Deprecated: the client sends its own token, this field is ignored
+	Token     string `form:"token" query:"-"`
 	Page      string `form:"page,omitempty" query:"page"`
 	Limit     string `form:"limit,omitempty" query:"limit"`         // Default value: 20. Maximum value: 200
 	Order     string `form:"order,omitempty" query:"order"`         // Default value: "created_at"
@@ -133,38 +130,12 @@ type ListDealsFilterResponse struct {
 	Total    int    `json:"total"`
 }
 
-func (s *Client) ListDealsFilter(ctx context.Context, filter ListDealsFilterRequest) (*ListDealsFilterResponse, error) {
-	queryString, err := StructToQueryString(filter)
-	if err != nil {
-		return nil, fmt.Errorf("error creating query string from filter: %w", err)
-	}
-
-	fullPath := listDealsEndpoint
-	if queryString != "" {
-		fullPath += "?" + queryString
-	}
-
-	resp, err := s.request(ctx, nil, http.MethodGet, fullPath)
-	if err != nil {
-		return nil, fmt.Errorf("%w: error making request to list deals: %w", ErrRequestFailed, err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, readErr := io.ReadAll(resp.Body)
-		if readErr != nil {
-			return nil, fmt.Errorf("%w: failed to list deals (status: %d), read response body error: %w", ErrReadResponseBody, resp.StatusCode, readErr)
-		}
-		bodyErr := errors.New(string(bodyBytes))
-		return nil, fmt.Errorf("%w: failed to list deals (status: %d): %w", ErrApiReturnedError, resp.StatusCode, bodyErr)
-	}
-
-	var responsePayload ListDealsFilterResponse
-	if err := json.NewDecoder(resp.Body).Decode(&responsePayload); err != nil {
-		return nil, fmt.Errorf("%w: error decoding list deals response: %w", ErrDecodeResponse, err)
-	}
+func (s *dealsService) List(ctx context.Context, filter ListDealsFilterRequest, opts ...CallOption) (*ListDealsFilterResponse, error) {
+	return do[ListDealsFilterResponse](withCallOptions(ctx, opts), s.client, listDealsEndpoint, filter)
+}
 
-	return &responsePayload, nil
+func (s *Client) ListDealsFilter(ctx context.Context, filter ListDealsFilterRequest, opts ...CallOption) (*ListDealsFilterResponse, error) {
+	return s.Deals.List(ctx, filter, opts...)
 }
 
 type DealProductData struct {
@@ -260,28 +231,12 @@ type CreateDealResponse struct {
 	Win                 *string                    `json:"win,omitempty"`
 }
 
-func (s *Client) CreateDeal(ctx context.Context, deal CreateDealRequest) (*CreateDealResponse, error) {
-	resp, err := s.request(ctx, deal, http.MethodPost, createDealEndpoint)
-	if err != nil {
-		return nil, fmt.Errorf("%w: error making request to create deal: %w", ErrRequestFailed, err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		bodyBytes, readErr := io.ReadAll(resp.Body)
-		if readErr != nil {
-			return nil, fmt.Errorf("%w: failed to create deal (status: %d), read response body error: %w", ErrReadResponseBody, resp.StatusCode, readErr)
-		}
-		bodyErr := errors.New(string(bodyBytes))
-		return nil, fmt.Errorf("%w: failed to create deal (status: %d): %w", ErrApiReturnedError, resp.StatusCode, bodyErr)
-	}
-
-	var responsePayload CreateDealResponse
-	if err := json.NewDecoder(resp.Body).Decode(&responsePayload); err != nil {
-		return nil, fmt.Errorf("%w: error decoding create deal response: %w", ErrDecodeResponse, err)
-	}
+func (s *dealsService) Create(ctx context.Context, deal CreateDealRequest, opts ...CallOption) (*CreateDealResponse, error) {
+	return do[CreateDealResponse](withCallOptions(ctx, opts), s.client, createDealEndpoint, deal)
+}
 
-	return &responsePayload, nil
+func (s *Client) CreateDeal(ctx context.Context, deal CreateDealRequest, opts ...CallOption) (*CreateDealResponse, error) {
+	return s.Deals.Create(ctx, deal, opts...)
 }
 
 type UpdateDealResponse struct {
@@ -392,27 +347,40 @@ type UserResponse struct {
 	Name string `json:"name"`
 }
 type UpdateDealRequest struct {
-	Campaign *UpdateCampaignRequestData `json:"campaign,omitempty"`
-	Deal     UpdateDealRequestData      `json:"deal"`
+	Campaign Optional[UpdateCampaignRequestData] `json:"campaign"`
+	Deal     UpdateDealRequestData               `json:"deal"`
+}
+
+func (r UpdateDealRequest) MarshalJSON() ([]byte, error) {
+	return marshalPartial(r)
 }
 
 type UpdateCampaignRequestData struct {
-	ID *string `json:"_id,omitempty"`
+	ID Optional[string] `json:"_id"`
 }
 
+func (d UpdateCampaignRequestData) MarshalJSON() ([]byte, error) {
+	return marshalPartial(d)
+}
+
+// UpdateDealRequestData holds the fields to change, the unset ones are left untouched
 type UpdateDealRequestData struct {
-	DealCustomFields *[]UpdateDealCustomFieldRequestData `json:"deal_custom_fields,omitempty"`
-	DealLostNote     *string                             `json:"deal_lost_note,omitempty"`
-	DealLostReasonID *string                             `json:"deal_lost_reason_id,omitempty"`
-	Hold             *string                             `json:"hold,omitempty"`
-	Name             *string                             `json:"name,omitempty"`
-	OrganizationID   *string                             `json:"organization_id,omitempty"`
-	PredictionDate   *string                             `json:"prediction_date,omitempty"`
-	Rating           *float64                            `json:"rating,omitempty"`
-	UserID           *string                             `json:"user_id,omitempty"`
-	Win              *string                             `json:"win,omitempty"`
-	DealSource       *UpdateDealSourceRequestData        `json:"deal_source,omitempty"`
-	DealStageID      *string                             `json:"deal_stage_id,omitempty"`
+	DealCustomFields Optional[[]UpdateDealCustomFieldRequestData] `json:"deal_custom_fields"`
+	DealLostNote     Optional[string]                             `json:"deal_lost_note"`
+	DealLostReasonID Optional[string]                             `json:"deal_lost_reason_id"`
+	Hold             Optional[string]                             `json:"hold"`
+	Name             Optional[string]                             `json:"name"`
+	OrganizationID   Optional[string]                             `json:"organization_id"`
+	PredictionDate   Optional[string]                             `json:"prediction_date"`
+	Rating           Optional[float64]                            `json:"rating"`
+	UserID           Optional[string]                             `json:"user_id"`
+	Win              Optional[string]                             `json:"win"`
+	DealSource       Optional[UpdateDealSourceRequestData]        `json:"deal_source"`
+	DealStageID      Optional[string]                             `json:"deal_stage_id"`
+}
+
+func (d UpdateDealRequestData) MarshalJSON() ([]byte, error) {
+	return marshalPartial(d)
 }
 
 type UpdateDealCustomFieldRequestData struct {
@@ -421,30 +389,28 @@ type UpdateDealCustomFieldRequestData struct {
 }
 
 type UpdateDealSourceRequestData struct {
-	ID          *string `json:"_id,omitempty"`
-	DealStageID *string `json:"deal_stage_id,omitempty"`
-}
-
-func (s *Client) UpdateDeal(ctx context.Context, dealID string, deal UpdateDealRequest) (*UpdateDealResponse, error) {
-	resp, err := s.request(ctx, deal, http.MethodPut, fmt.Sprintf(updateDealByIDEndpoint, dealID))
-	if err != nil {
-		return nil, fmt.Errorf("%w: error making request to update deal: %w", ErrRequestFailed, err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, readErr := io.ReadAll(resp.Body)
-		if readErr != nil {
-			return nil, fmt.Errorf("%w: failed to update deal (status: %d), read response body error: %w", ErrReadResponseBody, resp.StatusCode, readErr)
-		}
-		bodyErr := errors.New(string(bodyBytes))
-		return nil, fmt.Errorf("%w: failed to update deal (status: %d): %w", ErrApiReturnedError, resp.StatusCode, bodyErr)
-	}
-
-	var responsePayload UpdateDealResponse
-	if err := json.NewDecoder(resp.Body).Decode(&responsePayload); err != nil {
-		return nil, fmt.Errorf("%w: error decoding update deal response: %w", ErrDecodeResponse, err)
-	}
-
-	return &responsePayload, nil
+	ID          Optional[string] `json:"_id"`
+	DealStageID Optional[string] `json:"deal_stage_id"`
+}
+
+func (d UpdateDealSourceRequestData) MarshalJSON() ([]byte, error) {
+	return marshalPartial(d)
+}
+
+func (s *dealsService) Update(ctx context.Context, dealID string, deal UpdateDealRequest, opts ...CallOption) (*UpdateDealResponse, error) {
+	return do[UpdateDealResponse](withCallOptions(ctx, opts), s.client, updateDealByIDEndpoint, deal, dealID)
+}
+
+func (s *Client) UpdateDeal(ctx context.Context, dealID string, deal UpdateDealRequest, opts ...CallOption) (*UpdateDealResponse, error) {
+	return s.Deals.Update(ctx, dealID, deal, opts...)
+}
+
+type GetDealResponse UpdateDealResponse
+
+func (s *dealsService) Get(ctx context.Context, dealID string, opts ...CallOption) (*GetDealResponse, error) {
+	return do[GetDealResponse](withCallOptions(ctx, opts), s.client, getDealByIDEndpoint, nil, dealID)
+}
+
+func (s *Client) GetDeal(ctx context.Context, dealID string, opts ...CallOption) (*GetDealResponse, error) {
+	return s.Deals.Get(ctx, dealID, opts...)
 }
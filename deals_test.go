@@ -70,8 +70,8 @@ func TestUpdateDeal(t *testing.T) {
 
 	updateReq := rd_station.UpdateDealRequest{
 		Deal: rd_station.UpdateDealRequestData{
-			Name:         &updatedName,
-			DealLostNote: &updatedNote,
+			Name:         rd_station.Set(updatedName),
+			DealLostNote: rd_station.Set(updatedNote),
 		},
 	}
 
@@ -88,3 +88,65 @@ func TestUpdateDeal(t *testing.T) {
 		t.Logf("Updated deal lost note: %s", *updatedDeal.DealLostNote)
 	}
 }
+
+func TestGetDeal(t *testing.T) {
+	client := setupClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	existingDealID := os.Getenv("RD_TEST_DEAL_ID")
+	if existingDealID == "" {
+		t.Skip("RD_TEST_DEAL_ID environment variable not set, skipping test")
+	}
+
+	deal, err := client.GetDeal(ctx, existingDealID)
+	require.NoError(t, err)
+	require.NotNil(t, deal)
+
+	assert.Equal(t, existingDealID, deal.ID)
+
+	t.Logf("Successfully retrieved deal with ID: %s", deal.ID)
+}
+
+func TestListDealPipelines(t *testing.T) {
+	client := setupClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	pipelines, err := client.ListDealPipelines(ctx)
+	require.NoError(t, err)
+
+	for _, pipeline := range pipelines {
+		assert.NotEmpty(t, pipeline.ID)
+	}
+
+	t.Logf("Successfully retrieved %d deal pipelines", len(pipelines))
+}
+
+func TestMoveDealToStageOutsidePipeline(t *testing.T) {
+	client := setupClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	existingDealID := os.Getenv("RD_TEST_DEAL_ID")
+	if existingDealID == "" {
+		t.Skip("RD_TEST_DEAL_ID environment variable not set, skipping test")
+	}
+
+	_, err := client.MoveDealToStage(ctx, existingDealID, "000000000000000000000000")
+	require.ErrorIs(t, err, rd_station.ErrStageNotInPipeline)
+}
+
+func TestMarkDealLostWithUnknownReason(t *testing.T) {
+	client := setupClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	existingDealID := os.Getenv("RD_TEST_DEAL_ID")
+	if existingDealID == "" {
+		t.Skip("RD_TEST_DEAL_ID environment variable not set, skipping test")
+	}
+
+	_, err := client.MarkDealLost(ctx, existingDealID, "000000000000000000000000", "")
+	require.ErrorIs(t, err, rd_station.ErrLostReasonNotFound)
+}
@@ -0,0 +1,309 @@
+package rd_station
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// FieldChange is a field that differs between two snapshots. Custom fields are named
+// "custom_fields.<custom field id>".
+type FieldChange struct {
+	Field string
+	From  any
+	To    any
+}
+
+func (c FieldChange) String() string {
+	return fmt.Sprintf("%s: %s -> %s", c.Field, formatChangeValue(c.From), formatChangeValue(c.To))
+}
+
+// ChangeSet lists the changed fields, sorted by name
+type ChangeSet []FieldChange
+
+// String returns one change per line
+func (c ChangeSet) String() string {
+	lines := make([]string, len(c))
+	for i, change := range c {
+		lines[i] = change.String()
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Fields returns the names of the changed fields
+func (c ChangeSet) Fields() []string {
+	fields := make([]string, len(c))
+	for i, change := range c {
+		fields[i] = change.Field
+	}
+
+	return fields
+}
+
+// DiffDeal compares a deal fetched from the API with a modified copy and returns the update
+// that sends only the changed fields, along with the changes. Fields emptied in the copy are
+// cleared with null.
+func DiffDeal(current, modified Deal) (UpdateDealRequest, ChangeSet) {
+	var data UpdateDealRequestData
+	var changes ChangeSet
+
+	diffString(&changes, &data.Name, "name", current.Name, modified.Name)
+	diffString(&changes, &data.Hold, "hold", current.Hold, modified.Hold)
+	diffString(&changes, &data.Win, "win", current.Win, modified.Win)
+	diffString(&changes, &data.PredictionDate, "prediction_date", current.PredictionDate, modified.PredictionDate)
+	diffString(&changes, &data.DealStageID, "deal_stage_id", current.DealStage.ID, modified.DealStage.ID)
+	diffString(&changes, &data.UserID, "user_id", current.User.ID, modified.User.ID)
+
+	if current.Rating != modified.Rating {
+		data.Rating = Set(float64(modified.Rating))
+		changes = append(changes, FieldChange{Field: "rating", From: current.Rating, To: modified.Rating})
+	}
+
+	currentSource, modifiedSource := dealSourceID(current), dealSourceID(modified)
+	if currentSource != modifiedSource {
+		if modifiedSource == "" {
+			data.DealSource = Null[UpdateDealSourceRequestData]()
+		} else {
+			data.DealSource = Set(UpdateDealSourceRequestData{ID: Set(modifiedSource)})
+		}
+		changes = append(changes, FieldChange{Field: "deal_source", From: currentSource, To: modifiedSource})
+	}
+
+	var customFields []UpdateDealCustomFieldRequestData
+	for _, change := range diffCustomFields(dealCustomFieldValues(current.DealCustomFields), dealCustomFieldValues(modified.DealCustomFields)) {
+		customFields = append(customFields, UpdateDealCustomFieldRequestData{
+			CustomFieldID: strings.TrimPrefix(change.Field, customFieldChangePrefix),
+			Value:         change.To,
+		})
+		changes = append(changes, change)
+	}
+	if len(customFields) > 0 {
+		data.DealCustomFields = Set(customFields)
+	}
+
+	sortChanges(changes)
+
+	return UpdateDealRequest{Deal: data}, changes
+}
+
+// DiffContact compares a contact fetched from the API with a modified copy and returns the
+// update that sends only the changed fields, along with the changes. Fields emptied in the
+// copy are cleared with null, and changed lists are sent whole.
+func DiffContact(current, modified Contact) (UpdateContactRequest, ChangeSet) {
+	var data UpdateContactData
+	var changes ChangeSet
+
+	diffString(&changes, &data.Name, "name", current.Name, modified.Name)
+	diffString(&changes, &data.Facebook, "facebook", deref(current.Facebook), deref(modified.Facebook))
+	diffString(&changes, &data.LinkedIn, "linkedin", deref(current.LinkedIn), deref(modified.LinkedIn))
+	diffString(&changes, &data.Skype, "skype", deref(current.Skype), deref(modified.Skype))
+	diffString(&changes, &data.Title, "title", deref(current.Title), deref(modified.Title))
+	diffString(&changes, &data.OrganizationID, "organization_id", deref(current.OrganizationID), deref(modified.OrganizationID))
+
+	currentBirthday := BirthdayData{Day: current.Birthday.Day, Month: current.Birthday.Month, Year: current.Birthday.Year}
+	modifiedBirthday := BirthdayData{Day: modified.Birthday.Day, Month: modified.Birthday.Month, Year: modified.Birthday.Year}
+	if currentBirthday != modifiedBirthday {
+		if modifiedBirthday == (BirthdayData{}) {
+			data.Birthday = Null[BirthdayData]()
+		} else {
+			data.Birthday = Set(modifiedBirthday)
+		}
+		changes = append(changes, FieldChange{Field: "birthday", From: currentBirthday, To: modifiedBirthday})
+	}
+
+	currentEmails, modifiedEmails := contactEmails(current), contactEmails(modified)
+	if !slices.Equal(currentEmails, modifiedEmails) {
+		emails := make([]EmailData, len(modifiedEmails))
+		for i, email := range modifiedEmails {
+			emails[i] = EmailData{Email: email}
+		}
+		data.Emails = Set(emails)
+		changes = append(changes, FieldChange{Field: "emails", From: currentEmails, To: modifiedEmails})
+	}
+
+	currentPhones, modifiedPhones := contactPhones(current), contactPhones(modified)
+	if !slices.Equal(currentPhones, modifiedPhones) {
+		// the whole phones are sent, keeping their WhatsApp data
+		data.Phones = Set(append([]Phone{}, modified.Phones...))
+		changes = append(changes, FieldChange{Field: "phones", From: currentPhones, To: modifiedPhones})
+	}
+
+	currentDeals, modifiedDeals := contactDealIDs(current), contactDealIDs(modified)
+	if !slices.Equal(currentDeals, modifiedDeals) {
+		data.DealIDs = Set(modifiedDeals)
+		changes = append(changes, FieldChange{Field: "deal_ids", From: currentDeals, To: modifiedDeals})
+	}
+
+	if !reflect.DeepEqual(normalizeEmpty(current.LegalBases), normalizeEmpty(modified.LegalBases)) {
+		data.LegalBases = Set(slices.Clone(modified.LegalBases))
+		changes = append(changes, FieldChange{Field: "legal_bases", From: current.LegalBases, To: modified.LegalBases})
+	}
+
+	var customFields []ContactCustomField
+	for _, change := range diffCustomFields(contactCustomFieldValues(current), contactCustomFieldValues(modified)) {
+		value, _ := change.To.(string)
+		customFields = append(customFields, ContactCustomField{
+			CustomFieldID: strings.TrimPrefix(change.Field, customFieldChangePrefix),
+			Value:         value,
+		})
+		changes = append(changes, change)
+	}
+	if len(customFields) > 0 {
+		data.ContactCustomFields = Set(customFields)
+	}
+
+	sortChanges(changes)
+
+	return UpdateContactRequest{Contact: data}, changes
+}
+
+const customFieldChangePrefix = "custom_fields."
+
+// diffString sets the field when the values differ, clearing it when the new value is empty
+func diffString(changes *ChangeSet, field *Optional[string], name, from, to string) {
+	if from == to {
+		return
+	}
+
+	if to == "" {
+		*field = Null[string]()
+	} else {
+		*field = Set(to)
+	}
+
+	*changes = append(*changes, FieldChange{Field: name, From: from, To: to})
+}
+
+// diffCustomFields compares custom field values by id, removed fields change to nil
+func diffCustomFields(current, modified map[string]any) []FieldChange {
+	var changes []FieldChange
+	for id, value := range modified {
+		if previous, ok := current[id]; !ok || !reflect.DeepEqual(previous, value) {
+			changes = append(changes, FieldChange{Field: customFieldChangePrefix + id, From: current[id], To: value})
+		}
+	}
+
+	for id, value := range current {
+		if _, ok := modified[id]; !ok {
+			changes = append(changes, FieldChange{Field: customFieldChangePrefix + id, From: value})
+		}
+	}
+	sortChanges(changes)
+
+	return changes
+}
+
+// dealCustomFieldValues reads the untyped custom fields of a deal, identified either by
+// "custom_field_id" or by the id of the nested "custom_field"
+func dealCustomFieldValues(fields []interface{}) map[string]any {
+	values := map[string]any{}
+	for _, field := range fields {
+		object, ok := field.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		id, _ := object["custom_field_id"].(string)
+		if nested, ok := object["custom_field"].(map[string]interface{}); ok && id == "" {
+			for _, key := range []string{"custom_field_id", "id", "_id"} {
+				if id, _ = nested[key].(string); id != "" {
+					break
+				}
+			}
+		}
+
+		if id != "" {
+			values[id] = object["value"]
+		}
+	}
+
+	return values
+}
+
+func contactCustomFieldValues(contact Contact) map[string]any {
+	values := map[string]any{}
+	for _, field := range contact.ContactCustomFields {
+		values[field.CustomFieldID] = field.Value
+	}
+
+	return values
+}
+
+func dealSourceID(deal Deal) string {
+	if deal.DealSource == nil {
+		return ""
+	}
+
+	return deal.DealSource.ID
+}
+
+func contactEmails(contact Contact) []string {
+	emails := make([]string, len(contact.Emails))
+	for i, email := range contact.Emails {
+		emails[i] = email.Email
+	}
+
+	return emails
+}
+
+func contactPhones(contact Contact) []string {
+	phones := make([]string, len(contact.Phones))
+	for i, phone := range contact.Phones {
+		phones[i] = phone.Phone
+		if phone.Type != "" {
+			phones[i] += " (" + phone.Type + ")"
+		}
+		if phone.WhatsApp {
+			phones[i] += " [whatsapp]"
+		}
+	}
+
+	return phones
+}
+
+func contactDealIDs(contact Contact) []string {
+	ids := make([]string, len(contact.Deals))
+	for i, deal := range contact.Deals {
+		ids[i] = deal.ID
+	}
+
+	return ids
+}
+
+func normalizeEmpty[T any](values []T) []T {
+	if len(values) == 0 {
+		return nil
+	}
+
+	return values
+}
+
+func deref(value *string) string {
+	if value == nil {
+		return ""
+	}
+
+	return *value
+}
+
+func sortChanges(changes ChangeSet) {
+	slices.SortStableFunc(changes, func(a, b FieldChange) int {
+		return strings.Compare(a.Field, b.Field)
+	})
+}
+
+func formatChangeValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "<empty>"
+	case string:
+		if v == "" {
+			return "<empty>"
+		}
+		return fmt.Sprintf("%q", v)
+	}
+
+	return fmt.Sprintf("%v", value)
+}
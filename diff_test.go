@@ -0,0 +1,122 @@
+package rd_station_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rd_station "github.com/verbeux-ai/rd-station-go"
+)
+
+func TestDiffDeal(t *testing.T) {
+	current := rd_station.Deal{
+		ID:             "1",
+		Name:           "Website",
+		PredictionDate: "2024-03-01",
+		Rating:         3,
+		DealStage:      rd_station.DealStage{ID: "lead"},
+		User:           rd_station.User{ID: "ana"},
+		DealCustomFields: []interface{}{
+			map[string]interface{}{"custom_field_id": "budget", "value": "1000"},
+			map[string]interface{}{"custom_field": map[string]interface{}{"id": "channel"}, "value": "email"},
+		},
+	}
+
+	modified := current
+	modified.Name = "Website redesign"
+	modified.PredictionDate = ""
+	modified.DealStage.ID = "proposal"
+	modified.DealCustomFields = []interface{}{
+		map[string]interface{}{"custom_field_id": "budget", "value": "2000"},
+		map[string]interface{}{"custom_field": map[string]interface{}{"id": "channel"}, "value": "email"},
+	}
+
+	update, changes := rd_station.DiffDeal(current, modified)
+	assert.Equal(t, []string{"custom_fields.budget", "deal_stage_id", "name", "prediction_date"}, changes.Fields())
+	assert.Equal(t, `name: "Website" -> "Website redesign"`, changes[2].String())
+	assert.Equal(t, `prediction_date: "2024-03-01" -> <empty>`, changes[3].String())
+
+	data, err := json.Marshal(update)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"deal":{
+		"name":"Website redesign",
+		"prediction_date":null,
+		"deal_stage_id":"proposal",
+		"deal_custom_fields":[{"custom_field_id":"budget","value":"2000"}]
+	}}`, string(data))
+
+	_, changes = rd_station.DiffDeal(current, current)
+	assert.Empty(t, changes)
+}
+
+func TestDiffContact(t *testing.T) {
+	title := "CEO"
+	current := rd_station.Contact{
+		ID:     "1",
+		Name:   "Ana",
+		Title:  &title,
+		Emails: []rd_station.Email{{Email: "ana@example.com"}},
+		Phones: []rd_station.Phone{{Phone: "+5511987654321", Type: "cellphone"}},
+		ContactCustomFields: []rd_station.ContactCustomField{
+			{CustomFieldID: "segment", Value: "retail"},
+		},
+	}
+
+	modified := current
+	modified.Title = nil
+	modified.Phones = nil
+	modified.Emails = []rd_station.Email{{Email: "ana@example.com"}, {Email: "ana@work.com"}}
+	modified.ContactCustomFields = []rd_station.ContactCustomField{
+		{CustomFieldID: "segment", Value: "wholesale"},
+	}
+
+	update, changes := rd_station.DiffContact(current, modified)
+	assert.Equal(t, []string{"custom_fields.segment", "emails", "phones", "title"}, changes.Fields())
+
+	data, err := json.Marshal(update)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"contact":{
+		"contact_custom_fields":[{"_id":"","created_at":"","custom_field_id":"segment","updated_at":"","value":"wholesale"}],
+		"emails":[{"email":"ana@example.com"},{"email":"ana@work.com"}],
+		"phones":[],
+		"title":null
+	}}`, string(data))
+}
+
+func TestDiffContactKeepsWhatsAppData(t *testing.T) {
+	current := rd_station.Contact{
+		ID: "1",
+		Phones: []rd_station.Phone{{
+			Phone:                     "+5511987654321",
+			Type:                      "cellphone",
+			CreatedAt:                 "2024-01-01T10:00:00.000-03:00",
+			WhatsApp:                  true,
+			WhatsAppFullInternacional: "+5511987654321",
+			WhatsAppURLWeb:            "https://web.whatsapp.com/send?phone=5511987654321",
+		}},
+	}
+
+	// only the WhatsApp flag changes on the first phone, and a bare number is added
+	modified := current
+	modified.Phones = []rd_station.Phone{current.Phones[0], {Phone: "+551133334444", Type: "work"}}
+	modified.Phones[0].WhatsApp = false
+
+	update, changes := rd_station.DiffContact(current, modified)
+	require.Equal(t, []string{"phones"}, changes.Fields())
+	assert.Equal(t, []string{"+5511987654321 (cellphone) [whatsapp]"}, changes[0].From)
+
+	data, err := json.Marshal(update)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"contact":{"phones":[
+		{
+			"phone":"+5511987654321",
+			"type":"cellphone",
+			"created_at":"2024-01-01T10:00:00.000-03:00",
+			"whatsapp":false,
+			"whatsapp_full_internacional":"+5511987654321",
+			"whatsapp_url_web":"https://web.whatsapp.com/send?phone=5511987654321"
+		},
+		{"phone":"+551133334444","type":"work","whatsapp":false}
+	]}}`, string(data))
+}
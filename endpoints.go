@@ -1,11 +1,104 @@
 package rd_station
 
-const createContactEndpoint = "api/v1/contacts"
-const updateContactByIDEndpoint = "api/v1/contacts/%s"
-const listContactsEndpoint = "api/v1/contacts"
-const getContactByIDEndpoint = "api/v1/contacts/%s"
-
-const createDealEndpoint = "api/v1/deals"
-const updateDealByIDEndpoint = "api/v1/deals/%s"
-const listDealsEndpoint = "api/v1/deals"
-const getDealByIDEndpoint = "api/v1/deals/%s"
+import "net/http"
+
+// endpoint describes an API operation, sent by do
+type endpoint struct {
+	// operation names the request in RequestInfo, e.g.: "GetDeal"
+	operation string
+	// action describes the request in errors, e.g.: "get deal"
+	action string
+	method string
+	// path may have "%s" verbs replaced by the path params
+	path string
+	// statuses are the accepted status codes, only 200 when empty
+	statuses []int
+}
+
+var (
+	createContactEndpoint = endpoint{
+		operation: "CreateContact", action: "create contact",
+		method: http.MethodPost, path: "api/v1/contacts",
+		statuses: []int{http.StatusOK, http.StatusCreated},
+	}
+	updateContactByIDEndpoint = endpoint{
+		operation: "UpdateContact", action: "update contact",
+		method: http.MethodPut, path: "api/v1/contacts/%s",
+	}
+	listContactsEndpoint = endpoint{
+		operation: "ListContactsFilter", action: "list contacts",
+		method: http.MethodGet, path: "api/v1/contacts",
+	}
+	getContactByIDEndpoint = endpoint{
+		operation: "GetContact", action: "get contact",
+		method: http.MethodGet, path: "api/v1/contacts/%s",
+	}
+)
+
+var (
+	createDealEndpoint = endpoint{
+		operation: "CreateDeal", action: "create deal",
+		method: http.MethodPost, path: "api/v1/deals",
+		statuses: []int{http.StatusOK, http.StatusCreated},
+	}
+	updateDealByIDEndpoint = endpoint{
+		operation: "UpdateDeal", action: "update deal",
+		method: http.MethodPut, path: "api/v1/deals/%s",
+	}
+	listDealsEndpoint = endpoint{
+		operation: "ListDealsFilter", action: "list deals",
+		method: http.MethodGet, path: "api/v1/deals",
+	}
+	getDealByIDEndpoint = endpoint{
+		operation: "GetDeal", action: "get deal",
+		method: http.MethodGet, path: "api/v1/deals/%s",
+	}
+	listDealContactsEndpoint = endpoint{
+		operation: "ListDealContacts", action: "list deal contacts",
+		method: http.MethodGet, path: "api/v1/deals/%s/contacts",
+	}
+)
+
+var (
+	listDealPipelinesEndpoint = endpoint{
+		operation: "ListDealPipelines", action: "list deal pipelines",
+		method: http.MethodGet, path: "api/v1/deal_pipelines",
+	}
+	listDealStagesEndpoint = endpoint{
+		operation: "ListDealStagesFilter", action: "list deal stages",
+		method: http.MethodGet, path: "api/v1/deal_stages",
+	}
+	listDealLostReasonsEndpoint = endpoint{
+		operation: "ListDealLostReasons", action: "list deal lost reasons",
+		method: http.MethodGet, path: "api/v1/deal_lost_reasons",
+	}
+	createDealLostReasonEndpoint = endpoint{
+		operation: "CreateDealLostReason", action: "create deal lost reason",
+		method: http.MethodPost, path: "api/v1/deal_lost_reasons",
+		statuses: []int{http.StatusOK, http.StatusCreated},
+	}
+	updateDealLostReasonByIDEndpoint = endpoint{
+		operation: "UpdateDealLostReason", action: "update deal lost reason",
+		method: http.MethodPut, path: "api/v1/deal_lost_reasons/%s",
+	}
+)
+
+var listUsersEndpoint = endpoint{
+	operation: "ListUsers", action: "list users",
+	method: http.MethodGet, path: "api/v1/users",
+}
+
+var listActivitiesEndpoint = endpoint{
+	operation: "ListActivities", action: "list activities",
+	method: http.MethodGet, path: "api/v1/activities",
+}
+
+var getOrganizationByIDEndpoint = endpoint{
+	operation: "GetOrganization", action: "get organization",
+	method: http.MethodGet, path: "api/v1/organizations/%s",
+}
+
+var listCustomFieldsEndpoint = endpoint{
+	operation: "ListCustomFields", action: "list custom fields",
+	method: http.MethodGet, path: "api/v1/custom_fields",
+}
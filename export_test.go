@@ -0,0 +1,9 @@
+package rd_station
+
+var (
+	// MarshalPartial exposes marshalPartial to the tests of the embedded fields, which no
+	// payload of the package has yet
+	MarshalPartial = marshalPartial
+	// WithCallOptions exposes withCallOptions to simulate the helpers calling other methods
+	WithCallOptions = withCallOptions
+)
@@ -0,0 +1,537 @@
+// Package lgpd handles LGPD data subject requests: it locates the CRM records of a person by
+// email or phone, exports them as a JSON bundle and anonymizes them, keeping an auditable
+// report of every step.
+//
+// Contacts match on the exact email, ignoring case, or on the exact phone once both numbers
+// are normalized to E.164, with or without the ninth digit of Brazilian mobiles. The phone
+// is searched in every format it may have been stored with. The bundle also holds the deals of the contacts, their activities
+// and the organizations of both. The CRM API has no endpoints to delete records, so deletions
+// are reported as unsupported.
+//
+// Anonymizing can't be undone, so run it with WithDryRun first to review what would change:
+//
+//	_, plan, err := lgpd.New(client, lgpd.WithDryRun()).Anonymize(ctx, subject)
+//	// review plan.Entries, then
+//	bundle, report, err := lgpd.New(client).Anonymize(ctx, subject)
+package lgpd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	rd_station "github.com/verbeux-ai/rd-station-go"
+)
+
+const (
+	defaultPageSize       = 200
+	defaultAnonymizedName = "Anonymized contact"
+)
+
+var (
+	ErrEmptySubject        = errors.New("subject has no email nor phone")
+	ErrDeletionUnsupported = errors.New("the CRM API doesn't support deleting records")
+)
+
+// Limitations are recorded in every bundle, so the data subject knows what the export covers
+var Limitations = []string{
+	"the tasks of the deals aren't exported",
+	"contact notes and deal activities can't be changed through the CRM API and aren't anonymized",
+	"deals and organizations aren't anonymized: their names and custom fields may still mention the data subject",
+}
+
+type EntityType string
+
+const (
+	EntityContact      EntityType = "contact"
+	EntityDeal         EntityType = "deal"
+	EntityOrganization EntityType = "organization"
+	EntityActivity     EntityType = "activity"
+)
+
+type Action string
+
+const (
+	ActionFind      Action = "find"
+	ActionExport    Action = "export"
+	ActionAnonymize Action = "anonymize"
+	ActionDelete    Action = "delete"
+)
+
+type Outcome string
+
+const (
+	OutcomeDone        Outcome = "done"
+	OutcomeFailed      Outcome = "failed"
+	OutcomeUnsupported Outcome = "unsupported"
+	// OutcomePlanned is a step a dry run skipped, see WithDryRun
+	OutcomePlanned Outcome = "planned"
+)
+
+// Subject identifies the person who made the request, by email, phone or both
+type Subject struct {
+	Email string `json:"email,omitempty"`
+	Phone string `json:"phone,omitempty"`
+}
+
+// Bundle holds every record found for a subject, as returned by the API
+type Bundle struct {
+	Subject       Subject                      `json:"subject"`
+	GeneratedAt   time.Time                    `json:"generated_at"`
+	Contacts      []rd_station.Contact         `json:"contacts"`
+	Deals         []rd_station.GetDealResponse `json:"deals"`
+	Activities    []rd_station.Activity        `json:"activities"`
+	Organizations []rd_station.Organization    `json:"organizations"`
+	Limitations   []string                     `json:"limitations"`
+}
+
+// WriteJSON writes the bundle as indented JSON
+func (b *Bundle) WriteJSON(w io.Writer) error {
+	return writeJSON(w, b)
+}
+
+// ReportEntry is a step of the request on a record
+type ReportEntry struct {
+	Time    time.Time  `json:"time"`
+	Action  Action     `json:"action"`
+	Entity  EntityType `json:"entity"`
+	ID      string     `json:"id"`
+	Outcome Outcome    `json:"outcome"`
+	// Fields lists the anonymized fields
+	Fields []string `json:"fields,omitempty"`
+	Detail string   `json:"detail,omitempty"`
+}
+
+// Report is the audit trail of a request
+type Report struct {
+	Subject    Subject       `json:"subject"`
+	Action     Action        `json:"action"`
+	StartedAt  time.Time     `json:"started_at"`
+	FinishedAt time.Time     `json:"finished_at"`
+	Entries    []ReportEntry `json:"entries"`
+}
+
+// WriteJSON writes the report as indented JSON
+func (r *Report) WriteJSON(w io.Writer) error {
+	return writeJSON(w, r)
+}
+
+// Failed reports whether any step failed
+func (r *Report) Failed() bool {
+	return slices.ContainsFunc(r.Entries, func(entry ReportEntry) bool {
+		return entry.Outcome == OutcomeFailed
+	})
+}
+
+// Source reads and updates the records, implemented by *rd_station.Client
+type Source interface {
+	ListContactsFilter(ctx context.Context, filter rd_station.ListContactsFilterRequest, opts ...rd_station.CallOption) (*rd_station.ListContactsFilterResponse, error)
+	GetDeal(ctx context.Context, dealID string, opts ...rd_station.CallOption) (*rd_station.GetDealResponse, error)
+	ListActivities(ctx context.Context, filter rd_station.ListActivitiesFilterRequest, opts ...rd_station.CallOption) (*rd_station.ListActivitiesResponse, error)
+	GetOrganization(ctx context.Context, organizationID string, opts ...rd_station.CallOption) (*rd_station.Organization, error)
+	UpdateContact(ctx context.Context, contactID string, contact rd_station.UpdateContactRequest, opts ...rd_station.CallOption) (*rd_station.UpdateContactResponse, error)
+}
+
+type config struct {
+	pageSize        int
+	anonymizedName  string
+	phoneNormalizer rd_station.PhoneNormalizer
+	dryRun          bool
+	now             func() time.Time
+}
+
+// Option is a function that configures a Processor
+type Option func(*config)
+
+// WithPageSize sets the number of contacts requested per page, 200 by default. Sizes outside 1
+// to rd_station.MaxPageLimit are ignored, the API would reject them.
+func WithPageSize(size int) Option {
+	return func(c *config) {
+		if size >= 1 && size <= rd_station.MaxPageLimit {
+			c.pageSize = size
+		}
+	}
+}
+
+// WithAnonymizedName sets the name given to anonymized contacts, "Anonymized contact" by default
+func WithAnonymizedName(name string) Option {
+	return func(c *config) {
+		c.anonymizedName = name
+	}
+}
+
+// WithPhoneNormalizer sets how the phones are normalized before comparing them,
+// rd_station.DefaultPhoneNormalizer by default. Numbers it can't parse never match.
+func WithPhoneNormalizer(normalizer rd_station.PhoneNormalizer) Option {
+	return func(c *config) {
+		c.phoneNormalizer = normalizer
+	}
+}
+
+// WithDryRun makes Anonymize report the changes it would make, as OutcomePlanned entries,
+// without updating any record
+func WithDryRun() Option {
+	return func(c *config) {
+		c.dryRun = true
+	}
+}
+
+// WithNow sets the clock of the bundles and reports
+func WithNow(now func() time.Time) Option {
+	return func(c *config) {
+		c.now = now
+	}
+}
+
+// Processor handles the data subject requests
+type Processor struct {
+	source Source
+	config config
+}
+
+// New creates a processor reading and updating the records through source
+func New(source Source, opts ...Option) *Processor {
+	c := config{
+		pageSize:        defaultPageSize,
+		anonymizedName:  defaultAnonymizedName,
+		phoneNormalizer: rd_station.DefaultPhoneNormalizer(),
+		now:             time.Now,
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return &Processor{source: source, config: c}
+}
+
+// Find locates the contacts matching the email or phone of the subject, along with their
+// deals, the activities of the deals and the organizations of both
+func (p *Processor) Find(ctx context.Context, subject Subject) (*Bundle, error) {
+	if subject.Email == "" && subject.Phone == "" {
+		return nil, ErrEmptySubject
+	}
+
+	// the phone is searched in every format it may have been stored with, and matches in
+	// the E.164 forms with and without the ninth digit WhatsApp may report
+	var phoneVariants, phones []string
+	if subject.Phone != "" {
+		parsed, err := p.config.phoneNormalizer.Parse(subject.Phone)
+		if err != nil {
+			return nil, fmt.Errorf("error normalizing subject phone: %w", err)
+		}
+		phoneVariants = parsed.Variants()
+		for _, variant := range phoneVariants {
+			if strings.HasPrefix(variant, "+") {
+				phones = append(phones, variant)
+			}
+		}
+	}
+
+	bundle := &Bundle{
+		Subject:       subject,
+		GeneratedAt:   p.config.now(),
+		Contacts:      []rd_station.Contact{},
+		Deals:         []rd_station.GetDealResponse{},
+		Activities:    []rd_station.Activity{},
+		Organizations: []rd_station.Organization{},
+		Limitations:   Limitations,
+	}
+
+	seen := map[string]bool{}
+	addContacts := func(filter rd_station.ListContactsFilterRequest) error {
+		contacts, err := p.listContacts(ctx, filter)
+		if err != nil {
+			return err
+		}
+
+		for _, contact := range contacts {
+			if !seen[contact.ID] && p.matches(contact, subject.Email, phones) {
+				seen[contact.ID] = true
+				bundle.Contacts = append(bundle.Contacts, contact)
+			}
+		}
+		return nil
+	}
+
+	if subject.Email != "" {
+		if err := addContacts(rd_station.ListContactsFilterRequest{Email: subject.Email}); err != nil {
+			return nil, err
+		}
+	}
+	for _, variant := range phoneVariants {
+		if err := addContacts(rd_station.ListContactsFilterRequest{Phone: variant}); err != nil {
+			return nil, err
+		}
+	}
+
+	var organizationIDs []string
+	addOrganization := func(id string) {
+		if id != "" && !slices.Contains(organizationIDs, id) {
+			organizationIDs = append(organizationIDs, id)
+		}
+	}
+
+	deals := map[string]bool{}
+	for _, contact := range bundle.Contacts {
+		if contact.OrganizationID != nil {
+			addOrganization(*contact.OrganizationID)
+		}
+
+		for _, contactDeal := range contact.Deals {
+			if deals[contactDeal.ID] {
+				continue
+			}
+			deals[contactDeal.ID] = true
+
+			deal, err := p.source.GetDeal(ctx, contactDeal.ID)
+			if err != nil {
+				return nil, fmt.Errorf("error getting deal %s of contact %s: %w", contactDeal.ID, contact.ID, err)
+			}
+			bundle.Deals = append(bundle.Deals, *deal)
+
+			activities, err := p.listActivities(ctx, deal.ID)
+			if err != nil {
+				return nil, err
+			}
+			bundle.Activities = append(bundle.Activities, activities...)
+
+			if deal.Organization != nil {
+				addOrganization(deal.Organization.ID)
+			}
+		}
+	}
+
+	for _, id := range organizationIDs {
+		organization, err := p.source.GetOrganization(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("error getting organization %s: %w", id, err)
+		}
+		bundle.Organizations = append(bundle.Organizations, *organization)
+	}
+
+	return bundle, nil
+}
+
+// Export finds the records of the subject and writes them to w as a JSON bundle
+func (p *Processor) Export(ctx context.Context, subject Subject, w io.Writer) (*Bundle, *Report, error) {
+	report := p.newReport(subject, ActionExport)
+
+	bundle, err := p.Find(ctx, subject)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = bundle.WriteJSON(w)
+	outcome, detail := OutcomeDone, ""
+	if err != nil {
+		outcome, detail = OutcomeFailed, err.Error()
+	}
+	p.recordBundle(report, bundle, ActionExport, outcome, detail)
+	report.FinishedAt = p.config.now()
+
+	if err != nil {
+		return bundle, report, fmt.Errorf("error writing bundle: %w", err)
+	}
+
+	return bundle, report, nil
+}
+
+// Anonymize finds the records of the subject and overwrites the personal data of the contacts:
+// the name is replaced and the emails, phones, birthday, social profiles, title and custom
+// fields are cleared. The returned bundle holds the records as they were before. Failures are
+// recorded in the report, which lists every contact, and returned joined. With WithDryRun,
+// nothing is updated and the report lists the fields that would be cleared.
+func (p *Processor) Anonymize(ctx context.Context, subject Subject) (*Bundle, *Report, error) {
+	report := p.newReport(subject, ActionAnonymize)
+
+	bundle, err := p.Find(ctx, subject)
+	if err != nil {
+		return nil, nil, err
+	}
+	p.recordBundle(report, bundle, ActionFind, OutcomeDone, "")
+
+	var errs []error
+	for _, contact := range bundle.Contacts {
+		update, changes := rd_station.DiffContact(contact, p.anonymized(contact))
+
+		entry := ReportEntry{
+			Time:    p.config.now(),
+			Action:  ActionAnonymize,
+			Entity:  EntityContact,
+			ID:      contact.ID,
+			Outcome: OutcomeDone,
+			Fields:  changes.Fields(),
+		}
+
+		switch {
+		case len(changes) > 0 && p.config.dryRun:
+			entry.Outcome, entry.Detail = OutcomePlanned, "dry run"
+		case len(changes) > 0:
+			if _, err := p.source.UpdateContact(ctx, contact.ID, update); err != nil {
+				entry.Outcome, entry.Detail = OutcomeFailed, err.Error()
+				errs = append(errs, fmt.Errorf("error anonymizing contact %s: %w", contact.ID, err))
+			}
+		}
+		if entry.Outcome == OutcomeDone && contact.Notes != "" {
+			entry.Detail = "notes kept, the CRM API can't change them"
+		}
+
+		report.Entries = append(report.Entries, entry)
+	}
+	report.FinishedAt = p.config.now()
+
+	return bundle, report, errors.Join(errs...)
+}
+
+// Delete finds the records of the subject and reports each of them as not deleted, since
+// the CRM API can't delete records. It returns ErrDeletionUnsupported when any was found,
+// Anonymize is the alternative.
+func (p *Processor) Delete(ctx context.Context, subject Subject) (*Bundle, *Report, error) {
+	report := p.newReport(subject, ActionDelete)
+
+	bundle, err := p.Find(ctx, subject)
+	if err != nil {
+		return nil, nil, err
+	}
+	p.recordBundle(report, bundle, ActionDelete, OutcomeUnsupported, ErrDeletionUnsupported.Error())
+	report.FinishedAt = p.config.now()
+
+	if len(bundle.Contacts) > 0 {
+		return bundle, report, ErrDeletionUnsupported
+	}
+
+	return bundle, report, nil
+}
+
+func (p *Processor) listContacts(ctx context.Context, filter rd_station.ListContactsFilterRequest) ([]rd_station.Contact, error) {
+	filter.Limit = strconv.Itoa(p.config.pageSize)
+
+	var contacts []rd_station.Contact
+	for page := 1; ; page++ {
+		filter.Page = strconv.Itoa(page)
+
+		response, err := p.source.ListContactsFilter(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("error listing contacts: %w", err)
+		}
+		contacts = append(contacts, response.Contacts...)
+
+		if !response.HasMore || len(response.Contacts) == 0 {
+			return contacts, nil
+		}
+	}
+}
+
+func (p *Processor) listActivities(ctx context.Context, dealID string) ([]rd_station.Activity, error) {
+	filter := rd_station.ListActivitiesFilterRequest{DealID: dealID, Limit: strconv.Itoa(p.config.pageSize)}
+
+	var activities []rd_station.Activity
+	for page := 1; ; page++ {
+		filter.Page = strconv.Itoa(page)
+
+		response, err := p.source.ListActivities(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("error listing activities of deal %s: %w", dealID, err)
+		}
+		activities = append(activities, response.Activities...)
+
+		if !response.HasMore || len(response.Activities) == 0 {
+			return activities, nil
+		}
+	}
+}
+
+// anonymized returns the contact without personal data
+func (p *Processor) anonymized(contact rd_station.Contact) rd_station.Contact {
+	anonymized := contact
+	anonymized.Name = p.config.anonymizedName
+	anonymized.Emails = nil
+	anonymized.Phones = nil
+	anonymized.Birthday = rd_station.BirthdayResponse{}
+	anonymized.Facebook = nil
+	anonymized.LinkedIn = nil
+	anonymized.Skype = nil
+	anonymized.Title = nil
+
+	anonymized.ContactCustomFields = make([]rd_station.ContactCustomField, len(contact.ContactCustomFields))
+	for i, field := range contact.ContactCustomFields {
+		field.Value = ""
+		anonymized.ContactCustomFields[i] = field
+	}
+
+	return anonymized
+}
+
+func (p *Processor) newReport(subject Subject, action Action) *Report {
+	return &Report{
+		Subject:   subject,
+		Action:    action,
+		StartedAt: p.config.now(),
+		Entries:   []ReportEntry{},
+	}
+}
+
+// recordBundle adds an entry for every record of the bundle
+func (p *Processor) recordBundle(report *Report, bundle *Bundle, action Action, outcome Outcome, detail string) {
+	now := p.config.now()
+	add := func(entity EntityType, id string) {
+		report.Entries = append(report.Entries, ReportEntry{
+			Time:    now,
+			Action:  action,
+			Entity:  entity,
+			ID:      id,
+			Outcome: outcome,
+			Detail:  detail,
+		})
+	}
+
+	for _, contact := range bundle.Contacts {
+		add(EntityContact, contact.ID)
+	}
+	for _, deal := range bundle.Deals {
+		add(EntityDeal, deal.ID)
+	}
+	for _, activity := range bundle.Activities {
+		add(EntityActivity, activity.ID)
+	}
+	if action != ActionDelete {
+		for _, organization := range bundle.Organizations {
+			add(EntityOrganization, organization.ID)
+		}
+	}
+}
+
+// matches filters out the contacts the API returned for partial matches: the email must be
+// the same, ignoring case, or the phone one of the E.164 forms of the subject once normalized
+func (p *Processor) matches(contact rd_station.Contact, email string, phones []string) bool {
+	if email != "" {
+		for _, contactEmail := range contact.Emails {
+			if strings.EqualFold(strings.TrimSpace(contactEmail.Email), strings.TrimSpace(email)) {
+				return true
+			}
+		}
+	}
+
+	if len(phones) > 0 {
+		for _, contactPhone := range contact.Phones {
+			if normalized, err := p.config.phoneNormalizer.Normalize(contactPhone.Phone); err == nil && slices.Contains(phones, normalized) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func writeJSON(w io.Writer, value any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(value)
+}
@@ -0,0 +1,221 @@
+package lgpd_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rd_station "github.com/verbeux-ai/rd-station-go"
+	"github.com/verbeux-ai/rd-station-go/lgpd"
+)
+
+var _ lgpd.Source = (*rd_station.Client)(nil)
+
+type fakeSource struct {
+	contacts   []rd_station.Contact
+	deals      map[string]rd_station.GetDealResponse
+	activities []rd_station.Activity
+	updates    map[string]rd_station.UpdateContactRequest
+	updateErr  error
+	// exactPhones makes the phone filter match only the phones stored in the same format
+	exactPhones bool
+}
+
+func (f *fakeSource) ListContactsFilter(ctx context.Context, filter rd_station.ListContactsFilterRequest, opts ...rd_station.CallOption) (*rd_station.ListContactsFilterResponse, error) {
+	if f.exactPhones && filter.Phone != "" {
+		var contacts []rd_station.Contact
+		for _, contact := range f.contacts {
+			if slices.ContainsFunc(contact.Phones, func(phone rd_station.Phone) bool { return phone.Phone == filter.Phone }) {
+				contacts = append(contacts, contact)
+			}
+		}
+		return &rd_station.ListContactsFilterResponse{Contacts: contacts}, nil
+	}
+
+	// the API also returns partial matches, which the processor must filter out
+	return &rd_station.ListContactsFilterResponse{Contacts: f.contacts}, nil
+}
+
+func (f *fakeSource) GetDeal(ctx context.Context, dealID string, opts ...rd_station.CallOption) (*rd_station.GetDealResponse, error) {
+	deal := f.deals[dealID]
+	return &deal, nil
+}
+
+func (f *fakeSource) ListActivities(ctx context.Context, filter rd_station.ListActivitiesFilterRequest, opts ...rd_station.CallOption) (*rd_station.ListActivitiesResponse, error) {
+	var activities []rd_station.Activity
+	for _, activity := range f.activities {
+		if activity.DealID == filter.DealID {
+			activities = append(activities, activity)
+		}
+	}
+	return &rd_station.ListActivitiesResponse{Activities: activities}, nil
+}
+
+func (f *fakeSource) GetOrganization(ctx context.Context, organizationID string, opts ...rd_station.CallOption) (*rd_station.Organization, error) {
+	return &rd_station.Organization{ID: organizationID, Name: "Org " + organizationID}, nil
+}
+
+func (f *fakeSource) UpdateContact(ctx context.Context, contactID string, contact rd_station.UpdateContactRequest, opts ...rd_station.CallOption) (*rd_station.UpdateContactResponse, error) {
+	if f.updateErr != nil {
+		return nil, f.updateErr
+	}
+	f.updates[contactID] = contact
+	return &rd_station.UpdateContactResponse{ID: contactID}, nil
+}
+
+func newFakeSource() *fakeSource {
+	organizationID := "org-1"
+	return &fakeSource{
+		contacts: []rd_station.Contact{
+			{
+				ID:             "c1",
+				Name:           "Maria Silva",
+				Emails:         []rd_station.Email{{Email: "Maria@Example.com"}},
+				Phones:         []rd_station.Phone{{Phone: "+55 11 98765-4321"}},
+				OrganizationID: &organizationID,
+				Deals:          []rd_station.ContactDeal{{ID: "d1"}},
+				ContactCustomFields: []rd_station.ContactCustomField{
+					{CustomFieldID: "cpf", Value: "123.456.789-00"},
+				},
+				Notes: "called on monday",
+			},
+			{
+				ID:     "c2",
+				Name:   "Mariana",
+				Emails: []rd_station.Email{{Email: "mariana@example.com"}},
+			},
+			{
+				// the same local number without area code, which may be someone else's
+				ID:     "c3",
+				Name:   "Marta",
+				Phones: []rd_station.Phone{{Phone: "98765-4321"}},
+			},
+		},
+		deals: map[string]rd_station.GetDealResponse{
+			"d1": {ID: "d1", Name: "Website", Organization: &rd_station.OrganizationResponse{ID: "org-2"}},
+		},
+		activities: []rd_station.Activity{
+			{ID: "a1", DealID: "d1", Text: "Maria asked for a discount"},
+			{ID: "a2", DealID: "d9"},
+		},
+		updates: map[string]rd_station.UpdateContactRequest{},
+	}
+}
+
+func TestExport(t *testing.T) {
+	source := newFakeSource()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	processor := lgpd.New(source, lgpd.WithNow(func() time.Time { return now }))
+
+	var out bytes.Buffer
+	bundle, report, err := processor.Export(context.Background(), lgpd.Subject{Email: "maria@example.com", Phone: "11987654321"}, &out)
+	require.NoError(t, err)
+
+	require.Len(t, bundle.Contacts, 1)
+	assert.Equal(t, "c1", bundle.Contacts[0].ID)
+	require.Len(t, bundle.Organizations, 2)
+	assert.Equal(t, "Org org-2", bundle.Organizations[1].Name)
+	require.Len(t, bundle.Deals, 1)
+
+	var exported lgpd.Bundle
+	require.NoError(t, json.Unmarshal(out.Bytes(), &exported))
+	assert.Equal(t, "called on monday", exported.Contacts[0].Notes)
+	assert.Equal(t, []rd_station.Activity{{ID: "a1", DealID: "d1", Text: "Maria asked for a discount"}}, exported.Activities)
+	assert.NotEmpty(t, exported.Limitations)
+
+	assert.Len(t, report.Entries, 5)
+	assert.False(t, report.Failed())
+	assert.Equal(t, now, report.FinishedAt)
+}
+
+func TestAnonymize(t *testing.T) {
+	source := newFakeSource()
+	processor := lgpd.New(source, lgpd.WithAnonymizedName("Titular removido"))
+
+	bundle, report, err := processor.Anonymize(context.Background(), lgpd.Subject{Phone: "(11) 98765-4321"})
+	require.NoError(t, err)
+	assert.Equal(t, "Maria Silva", bundle.Contacts[0].Name, "the bundle keeps the original data")
+
+	update := source.updates["c1"].Contact
+	name, _ := update.Name.Get()
+	assert.Equal(t, "Titular removido", name)
+	emails, _ := update.Emails.Get()
+	assert.Empty(t, emails)
+	fields, _ := update.ContactCustomFields.Get()
+	assert.Equal(t, []rd_station.ContactCustomField{{CustomFieldID: "cpf"}}, fields)
+	assert.False(t, update.DealIDs.IsSet(), "the deals stay linked")
+
+	entry := report.Entries[len(report.Entries)-1]
+	assert.Equal(t, lgpd.ActionAnonymize, entry.Action)
+	assert.Equal(t, lgpd.OutcomeDone, entry.Outcome)
+	assert.Equal(t, []string{"custom_fields.cpf", "emails", "name", "phones"}, entry.Fields)
+	assert.Contains(t, entry.Detail, "notes kept")
+
+	source.updateErr = errors.New("boom")
+	_, report, err = processor.Anonymize(context.Background(), lgpd.Subject{Email: "maria@example.com"})
+	assert.ErrorContains(t, err, "error anonymizing contact c1")
+	assert.True(t, report.Failed())
+}
+
+func TestFindMatchesWholePhones(t *testing.T) {
+	processor := lgpd.New(newFakeSource())
+
+	bundle, err := processor.Find(context.Background(), lgpd.Subject{Phone: "+55 (11) 98765-4321"})
+	require.NoError(t, err)
+	require.Len(t, bundle.Contacts, 1, "numbers sharing only the last digits don't match")
+	assert.Equal(t, "c1", bundle.Contacts[0].ID)
+
+	_, err = processor.Find(context.Background(), lgpd.Subject{Phone: "4321"})
+	assert.ErrorIs(t, err, rd_station.ErrInvalidPhone)
+}
+
+func TestFindSearchesPhoneVariants(t *testing.T) {
+	source := &fakeSource{
+		exactPhones: true,
+		contacts: []rd_station.Contact{
+			{ID: "c1", Phones: []rd_station.Phone{{Phone: "11987654321"}}},
+			// registered before the ninth digit
+			{ID: "c2", Phones: []rd_station.Phone{{Phone: "+551187654321"}}},
+			{ID: "c3", Phones: []rd_station.Phone{{Phone: "11912345678"}}},
+		},
+	}
+	processor := lgpd.New(source)
+
+	bundle, err := processor.Find(context.Background(), lgpd.Subject{Phone: "+55 (11) 98765-4321"})
+	require.NoError(t, err)
+	require.Len(t, bundle.Contacts, 2)
+	assert.Equal(t, "c1", bundle.Contacts[0].ID)
+	assert.Equal(t, "c2", bundle.Contacts[1].ID)
+}
+
+func TestAnonymizeDryRun(t *testing.T) {
+	source := newFakeSource()
+	processor := lgpd.New(source, lgpd.WithDryRun())
+
+	_, report, err := processor.Anonymize(context.Background(), lgpd.Subject{Email: "maria@example.com"})
+	require.NoError(t, err)
+	assert.Empty(t, source.updates)
+
+	entry := report.Entries[len(report.Entries)-1]
+	assert.Equal(t, lgpd.OutcomePlanned, entry.Outcome)
+	assert.Equal(t, []string{"custom_fields.cpf", "emails", "name", "phones"}, entry.Fields)
+}
+
+func TestDelete(t *testing.T) {
+	processor := lgpd.New(newFakeSource())
+
+	_, report, err := processor.Delete(context.Background(), lgpd.Subject{Email: "maria@example.com"})
+	assert.ErrorIs(t, err, lgpd.ErrDeletionUnsupported)
+	for _, entry := range report.Entries {
+		assert.Equal(t, lgpd.OutcomeUnsupported, entry.Outcome)
+	}
+
+	_, _, err = processor.Delete(context.Background(), lgpd.Subject{})
+	assert.ErrorIs(t, err, lgpd.ErrEmptySubject)
+}
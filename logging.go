@@ -0,0 +1,140 @@
+package rd_station
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const defaultMaxLoggedBodySize = 4096
+
+// defaultRedactedFields are the JSON keys always masked in logged bodies
+var defaultRedactedFields = []string{"token", "access_token", "refresh_token", "client_secret", "password"}
+
+// LogOptions configures the request logging enabled by WithLogger
+type LogOptions struct {
+	// Bodies logs the request and response bodies at debug level
+	Bodies bool
+	// MaxBodySize truncates the logged bodies, 4096 bytes by default
+	MaxBodySize int
+	// RedactFields are JSON keys masked in the logged bodies besides the credentials, e.g.: "email"
+	RedactFields []string
+}
+
+// WithLogger logs every API call: successful ones at debug level and failed ones at error level,
+// with the method, endpoint template, duration and status. The URLs are never logged, so the
+// credentials and identifiers in them stay out of the logs.
+func WithLogger(logger *slog.Logger, opts ...LogOptions) Option {
+	return func(c *Client) {
+		c.logger = logger
+		if len(opts) > 0 {
+			c.logOptions = opts[0]
+		}
+	}
+}
+
+// LoggingMiddleware logs the API calls as described in WithLogger
+func LoggingMiddleware(logger *slog.Logger, opts LogOptions) Middleware {
+	if opts.MaxBodySize <= 0 {
+		opts.MaxBodySize = defaultMaxLoggedBodySize
+	}
+
+	redactFields := map[string]bool{}
+	for _, field := range append(defaultRedactedFields, opts.RedactFields...) {
+		redactFields[strings.ToLower(field)] = true
+	}
+
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			ctx := req.Context()
+			info, _ := RequestInfoFromContext(ctx)
+			logBodies := opts.Bodies && logger.Enabled(ctx, slog.LevelDebug)
+
+			attrs := []slog.Attr{
+				slog.String("operation", info.Operation),
+				slog.String("method", req.Method),
+				slog.String("endpoint", info.EndpointTemplate),
+			}
+
+			if logBodies && req.GetBody != nil {
+				if body, err := req.GetBody(); err == nil {
+					data, _ := io.ReadAll(body)
+					body.Close()
+					attrs = append(attrs, slog.String("request_body", formatBody(data, redactFields, opts.MaxBodySize)))
+				}
+			}
+
+			start := time.Now()
+			resp, err := next.Do(req)
+			attrs = append(attrs, slog.Duration("duration", time.Since(start)))
+
+			if stats := RequestStatsFromContext(ctx); stats != nil && stats.Retries > 0 {
+				attrs = append(attrs, slog.Int("retries", stats.Retries))
+			}
+
+			if err != nil {
+				attrs = append(attrs, slog.String("error", err.Error()))
+				logger.LogAttrs(ctx, slog.LevelError, "rd station request failed", attrs...)
+				return resp, err
+			}
+
+			attrs = append(attrs, slog.Int("status", resp.StatusCode))
+
+			if logBodies {
+				data, readErr := io.ReadAll(resp.Body)
+				resp.Body.Close()
+				resp.Body = io.NopCloser(bytes.NewReader(data))
+				if readErr == nil {
+					attrs = append(attrs, slog.String("response_body", formatBody(data, redactFields, opts.MaxBodySize)))
+				}
+			}
+
+			level := slog.LevelDebug
+			if resp.StatusCode >= http.StatusBadRequest {
+				level = slog.LevelError
+			}
+			logger.LogAttrs(ctx, level, "rd station request", attrs...)
+
+			return resp, nil
+		})
+	}
+}
+
+// formatBody masks the redacted fields of JSON bodies and truncates the result
+func formatBody(data []byte, redactFields map[string]bool, maxSize int) string {
+	var decoded any
+	if err := json.Unmarshal(data, &decoded); err == nil {
+		if redactedData, err := json.Marshal(redactValue(decoded, redactFields)); err == nil {
+			data = redactedData
+		}
+	}
+
+	if len(data) > maxSize {
+		return string(data[:maxSize]) + "...(truncated)"
+	}
+
+	return string(data)
+}
+
+func redactValue(value any, redactFields map[string]bool) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, nested := range v {
+			if redactFields[strings.ToLower(key)] {
+				v[key] = redacted
+				continue
+			}
+			v[key] = redactValue(nested, redactFields)
+		}
+	case []any:
+		for i, nested := range v {
+			v[i] = redactValue(nested, redactFields)
+		}
+	}
+
+	return value
+}
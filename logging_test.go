@@ -0,0 +1,65 @@
+package rd_station_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rd_station "github.com/verbeux-ai/rd-station-go"
+)
+
+func TestWithLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"123","name":"Deal","token":"echoed-secret"}`))
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	client := rd_station.NewClient(
+		rd_station.WithBaseUrl(server.URL),
+		rd_station.WithToken("super-secret"),
+		rd_station.WithLogger(logger, rd_station.LogOptions{Bodies: true, RedactFields: []string{"name"}}),
+	)
+
+	deal, err := client.CreateDeal(context.Background(), rd_station.CreateDealRequest{Deal: rd_station.CreateDealData{Name: "Deal"}})
+	require.NoError(t, err)
+	assert.Equal(t, "Deal", deal.Name, "logging must not consume the response body")
+
+	output := logs.String()
+	assert.Contains(t, output, `"operation":"CreateDeal"`)
+	assert.Contains(t, output, `"endpoint":"api/v1/deals"`)
+	assert.Contains(t, output, `"status":201`)
+	assert.Contains(t, output, `"request_body":"{\"deal\":{\"name\":\"REDACTED\"}}"`)
+	assert.NotContains(t, output, "super-secret")
+	assert.NotContains(t, output, "echoed-secret")
+}
+
+func TestWithLoggerSkipsBodiesAboveDebug(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"errors":"invalid"}`))
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	client := rd_station.NewClient(rd_station.WithBaseUrl(server.URL), rd_station.WithLogger(logger, rd_station.LogOptions{Bodies: true}))
+
+	_, err := client.GetDeal(context.Background(), "123")
+	require.ErrorIs(t, err, rd_station.ErrApiReturnedError)
+	assert.Contains(t, err.Error(), "invalid")
+
+	output := logs.String()
+	assert.Contains(t, output, `"level":"ERROR"`)
+	assert.Contains(t, output, `"status":422`)
+	assert.NotContains(t, output, "response_body")
+}
@@ -0,0 +1,76 @@
+// Package marketing is a client for the RD Station Marketing API (api.rd.services).
+package marketing
+
+import (
+	"net/http"
+
+	rd_station "github.com/verbeux-ai/rd-station-go"
+	"github.com/verbeux-ai/rd-station-go/auth"
+)
+
+type Client struct {
+	authenticator rd_station.Authenticator
+	baseUrl       string
+	httpClient    *http.Client
+	retryPolicy   rd_station.RetryPolicy
+}
+
+// Option is a function that configures a client
+type Option func(*Client)
+
+// NewClient creates a new client with the provided options
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		baseUrl:    "https://api.rd.services",
+		httpClient: http.DefaultClient,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// WithAccessToken sets a fixed OAuth2 access token, which is never refreshed
+func WithAccessToken(accessToken string) Option {
+	return func(c *Client) {
+		c.authenticator = rd_station.BearerAuthenticator(accessToken)
+	}
+}
+
+// WithTokenSource sets the source of the OAuth2 access tokens. When the API rejects a token
+// and the source implements auth.Refresher, the token is refreshed and the request sent again.
+func WithTokenSource(tokenSource auth.TokenSource) Option {
+	return func(c *Client) {
+		c.authenticator = rd_station.OAuth2Authenticator(tokenSource)
+	}
+}
+
+// WithAuthenticator sets how the client adds credentials to the requests
+func WithAuthenticator(authenticator rd_station.Authenticator) Option {
+	return func(c *Client) {
+		c.authenticator = authenticator
+	}
+}
+
+// WithBaseUrl sets the base URL of the client
+func WithBaseUrl(baseUrl string) Option {
+	return func(c *Client) {
+		c.baseUrl = baseUrl
+	}
+}
+
+// WithHttpClient sets the http client of the client
+func WithHttpClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithRetryPolicy sets the policy used to retry failed requests
+func WithRetryPolicy(policy rd_station.RetryPolicy) Option {
+	return func(c *Client) {
+		c.retryPolicy = policy
+	}
+}
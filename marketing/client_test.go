@@ -0,0 +1,40 @@
+package marketing_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/verbeux-ai/rd-station-go/auth"
+	"github.com/verbeux-ai/rd-station-go/marketing"
+)
+
+func TestClientRefreshesRejectedToken(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /auth/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"access_token": "fresh", "refresh_token": "refresh", "expires_in": 86400})
+	})
+	mux.HandleFunc("GET /platform/contacts/{identifier}", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"uuid":"123","email":"lead@example.com"}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	source := auth.NewRefreshingTokenSource(
+		auth.Config{TokenURL: server.URL + "/auth/token"},
+		auth.NewMemoryStore(&auth.Token{AccessToken: "revoked", RefreshToken: "refresh"}),
+	)
+	client := marketing.NewClient(marketing.WithBaseUrl(server.URL), marketing.WithTokenSource(source))
+
+	contact, err := client.GetContact(context.Background(), marketing.ByEmail("lead@example.com"))
+	require.NoError(t, err)
+	assert.Equal(t, "123", contact.UUID)
+}
@@ -0,0 +1,99 @@
+package marketing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	rd_station "github.com/verbeux-ai/rd-station-go"
+)
+
+func (s *Client) request(ctx context.Context, reqBody any, method, endpoint string) (*http.Response, error) {
+	var marshalledBody []byte
+	if reqBody != nil {
+		var err error
+		marshalledBody, err = json.Marshal(reqBody)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	url := fmt.Sprintf("%s/%s", strings.TrimSuffix(s.baseUrl, "/"), endpoint)
+
+	resp, err := s.send(ctx, marshalledBody, method, url)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	refreshable, ok := s.authenticator.(rd_station.RefreshableAuthenticator)
+	if !ok {
+		return resp, nil
+	}
+
+	if err := refreshable.Refresh(ctx, resp.Request); err != nil {
+		// keep the 401 so the caller reports the original api error
+		return resp, nil
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	return s.send(ctx, marshalledBody, method, url)
+}
+
+func (s *Client) send(ctx context.Context, marshalledBody []byte, method, url string) (*http.Response, error) {
+	return s.retryPolicy.Do(ctx, method, func() (*http.Response, error) {
+		var bodyReader io.Reader
+		if marshalledBody != nil {
+			bodyReader = bytes.NewReader(marshalledBody)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("Content-Type", "application/json")
+		if s.authenticator != nil {
+			if err := s.authenticator.Authenticate(ctx, req); err != nil {
+				return nil, err
+			}
+		}
+
+		return s.httpClient.Do(req)
+	})
+}
+
+// do sends the request and decodes the response into responsePayload, when it's not nil.
+// operation describes the call in error messages, e.g.: "get contact".
+func (s *Client) do(ctx context.Context, operation string, reqBody any, method, endpoint string, responsePayload any, expectedStatuses ...int) error {
+	resp, err := s.request(ctx, reqBody, method, endpoint)
+	if err != nil {
+		return fmt.Errorf("%w: error making request to %s: %w", rd_station.ErrRequestFailed, operation, err)
+	}
+	defer resp.Body.Close()
+
+	if !slices.Contains(expectedStatuses, resp.StatusCode) {
+		bodyBytes, readErr := io.ReadAll(resp.Body)
+		if readErr != nil {
+			return fmt.Errorf("%w: failed to %s (status: %d), read response body error: %w", rd_station.ErrReadResponseBody, operation, resp.StatusCode, readErr)
+		}
+		bodyErr := errors.New(string(bodyBytes))
+		return fmt.Errorf("%w: failed to %s (status: %d): %w", rd_station.ErrApiReturnedError, operation, resp.StatusCode, bodyErr)
+	}
+
+	if responsePayload == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(responsePayload); err != nil {
+		return fmt.Errorf("%w: error decoding %s response: %w", rd_station.ErrDecodeResponse, operation, err)
+	}
+
+	return nil
+}
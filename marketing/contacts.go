@@ -0,0 +1,184 @@
+package marketing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	rd_station "github.com/verbeux-ai/rd-station-go"
+)
+
+const customFieldPrefix = "cf_"
+
+// ContactIdentifier identifies a contact in the paths of the contacts endpoints
+type ContactIdentifier struct {
+	Type  string
+	Value string
+}
+
+// ByUUID identifies a contact by its UUID
+func ByUUID(uuid string) ContactIdentifier {
+	return ContactIdentifier{Type: "uuid", Value: uuid}
+}
+
+// ByEmail identifies a contact by its email
+func ByEmail(email string) ContactIdentifier {
+	return ContactIdentifier{Type: "email", Value: email}
+}
+
+func (i ContactIdentifier) path() string {
+	return i.Type + ":" + url.PathEscape(i.Value)
+}
+
+type Contact struct {
+	UUID          string                  `json:"uuid"`
+	Name          string                  `json:"name"`
+	Email         string                  `json:"email"`
+	Bio           string                  `json:"bio"`
+	Website       string                  `json:"website"`
+	JobTitle      string                  `json:"job_title"`
+	PersonalPhone string                  `json:"personal_phone"`
+	MobilePhone   string                  `json:"mobile_phone"`
+	City          string                  `json:"city"`
+	State         string                  `json:"state"`
+	Country       string                  `json:"country"`
+	Twitter       string                  `json:"twitter"`
+	Facebook      string                  `json:"facebook"`
+	LinkedIn      string                  `json:"linkedin"`
+	Tags          []string                `json:"tags"`
+	LegalBases    []rd_station.LegalBasis `json:"legal_bases"`
+	Links         []Link                  `json:"links"`
+
+	// CustomFields holds the "cf_" fields keyed by their api identifier, e.g.: "cf_plan"
+	CustomFields map[string]any `json:"-"`
+}
+
+type Link struct {
+	Rel        string `json:"rel"`
+	Href       string `json:"href"`
+	Media      string `json:"media"`
+	Type       string `json:"type"`
+	Title      string `json:"title"`
+	Hreflang   string `json:"hreflang"`
+	Identifier string `json:"identifier"`
+}
+
+func (c *Contact) UnmarshalJSON(data []byte) error {
+	type contact Contact
+	if err := json.Unmarshal(data, (*contact)(c)); err != nil {
+		return err
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	c.CustomFields = extractCustomFields(fields)
+
+	return nil
+}
+
+// ContactData is the payload to create or update a contact. Nil fields are left unchanged.
+type ContactData struct {
+	Name          *string                  `json:"name,omitempty"`
+	Email         *string                  `json:"email,omitempty"`
+	Bio           *string                  `json:"bio,omitempty"`
+	Website       *string                  `json:"website,omitempty"`
+	JobTitle      *string                  `json:"job_title,omitempty"`
+	PersonalPhone *string                  `json:"personal_phone,omitempty"`
+	MobilePhone   *string                  `json:"mobile_phone,omitempty"`
+	City          *string                  `json:"city,omitempty"`
+	State         *string                  `json:"state,omitempty"`
+	Country       *string                  `json:"country,omitempty"`
+	Twitter       *string                  `json:"twitter,omitempty"`
+	Facebook      *string                  `json:"facebook,omitempty"`
+	LinkedIn      *string                  `json:"linkedin,omitempty"`
+	Tags          []string                 `json:"tags,omitempty"`
+	LegalBases    *[]rd_station.LegalBasis `json:"legal_bases,omitempty"`
+
+	// CustomFields are sent as top level "cf_" fields, the prefix is added when missing
+	CustomFields map[string]any `json:"-"`
+}
+
+func (c ContactData) MarshalJSON() ([]byte, error) {
+	type contactData ContactData
+	data, err := json.Marshal(contactData(c))
+	if err != nil || len(c.CustomFields) == 0 {
+		return data, err
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	for key, value := range c.CustomFields {
+		if !strings.HasPrefix(key, customFieldPrefix) {
+			key = customFieldPrefix + key
+		}
+		fields[key] = value
+	}
+
+	return json.Marshal(fields)
+}
+
+func (s *Client) GetContact(ctx context.Context, identifier ContactIdentifier) (*Contact, error) {
+	var responsePayload Contact
+	if err := s.do(ctx, "get contact", nil, http.MethodGet, fmt.Sprintf(contactEndpoint, identifier.path()), &responsePayload, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	return &responsePayload, nil
+}
+
+// UpsertContact creates the contact or updates the existing one. Only email and uuid identifiers
+// are accepted by the API, and only email creates missing contacts.
+func (s *Client) UpsertContact(ctx context.Context, identifier ContactIdentifier, contact ContactData) (*Contact, error) {
+	var responsePayload Contact
+	if err := s.do(ctx, "upsert contact", contact, http.MethodPatch, fmt.Sprintf(contactEndpoint, identifier.path()), &responsePayload, http.StatusOK, http.StatusCreated); err != nil {
+		return nil, err
+	}
+
+	return &responsePayload, nil
+}
+
+func (s *Client) DeleteContact(ctx context.Context, identifier ContactIdentifier) error {
+	return s.do(ctx, "delete contact", nil, http.MethodDelete, fmt.Sprintf(contactEndpoint, identifier.path()), nil, http.StatusOK, http.StatusNoContent)
+}
+
+type addTagsRequest struct {
+	Tags []string `json:"tags"`
+}
+
+type AddContactTagsResponse struct {
+	Tags []string `json:"tags"`
+}
+
+// AddContactTags adds tags to the contact, keeping the existing ones
+func (s *Client) AddContactTags(ctx context.Context, identifier ContactIdentifier, tags ...string) (*AddContactTagsResponse, error) {
+	var responsePayload AddContactTagsResponse
+	if err := s.do(ctx, "add contact tags", addTagsRequest{Tags: tags}, http.MethodPost, fmt.Sprintf(contactTagsEndpoint, identifier.path()), &responsePayload, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	return &responsePayload, nil
+}
+
+func extractCustomFields(fields map[string]any) map[string]any {
+	customFields := map[string]any{}
+	for key, value := range fields {
+		if strings.HasPrefix(key, customFieldPrefix) {
+			customFields[key] = value
+		}
+	}
+
+	if len(customFields) == 0 {
+		return nil
+	}
+
+	return customFields
+}
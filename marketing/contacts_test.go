@@ -0,0 +1,71 @@
+package marketing_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/verbeux-ai/rd-station-go/marketing"
+)
+
+func setupClient(t *testing.T) *marketing.Client {
+	token := os.Getenv("RD_STATION_MARKETING_TOKEN")
+	if token == "" {
+		t.Skip("RD_STATION_MARKETING_TOKEN environment variable not set, skipping test")
+	}
+	return marketing.NewClient(marketing.WithAccessToken(token))
+}
+
+func TestContactDataCustomFields(t *testing.T) {
+	name := "Teste"
+	data := marketing.ContactData{
+		Name:         &name,
+		CustomFields: map[string]any{"plan": "pro", "cf_seats": 3},
+	}
+
+	body, err := json.Marshal(data)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"Teste","cf_plan":"pro","cf_seats":3}`, string(body))
+
+	var contact marketing.Contact
+	require.NoError(t, json.Unmarshal([]byte(`{"uuid":"1","name":"Teste","cf_plan":"pro"}`), &contact))
+	assert.Equal(t, "1", contact.UUID)
+	assert.Equal(t, map[string]any{"cf_plan": "pro"}, contact.CustomFields)
+}
+
+func TestUpsertAndGetContact(t *testing.T) {
+	client := setupClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	email := "test_" + time.Now().Format("20060102150405") + "@example.com"
+	name := "Teste Automatizado"
+
+	created, err := client.UpsertContact(ctx, marketing.ByEmail(email), marketing.ContactData{Name: &name})
+	require.NoError(t, err)
+	require.NotNil(t, created)
+	assert.NotEmpty(t, created.UUID)
+
+	contact, err := client.GetContact(ctx, marketing.ByUUID(created.UUID))
+	require.NoError(t, err)
+	assert.Equal(t, email, contact.Email)
+	assert.Equal(t, name, contact.Name)
+
+	t.Logf("Successfully upserted contact with UUID: %s", contact.UUID)
+}
+
+func TestListFields(t *testing.T) {
+	client := setupClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	response, err := client.ListFields(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, response)
+
+	t.Logf("Successfully retrieved %d fields", len(response.Fields))
+}
@@ -0,0 +1,15 @@
+package marketing
+
+const contactEndpoint = "platform/contacts/%s"
+const contactFunnelEndpoint = "platform/contacts/%s/funnels/default"
+const contactTagsEndpoint = "platform/contacts/%s/tag"
+
+const listFieldsEndpoint = "platform/contacts/fields"
+const createFieldEndpoint = "platform/contacts/fields"
+const fieldByUUIDEndpoint = "platform/contacts/fields/%s"
+
+const listSegmentationsEndpoint = "platform/segmentations?page=%d&page_size=%d"
+const listSegmentationContactsEndpoint = "platform/segmentations/%s/contacts?page=%d&page_size=%d"
+
+const eventsEndpoint = "platform/events"
+const batchEventsEndpoint = "platform/events/batch"
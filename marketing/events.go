@@ -0,0 +1,410 @@
+package marketing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"strings"
+
+	rd_station "github.com/verbeux-ai/rd-station-go"
+)
+
+var (
+	ErrInvalidEvent = errors.New("invalid event")
+)
+
+type EventType string
+
+const (
+	EventTypeConversion      EventType = "CONVERSION"
+	EventTypeOpportunity     EventType = "OPPORTUNITY"
+	EventTypeSale            EventType = "SALE"
+	EventTypeOpportunityLost EventType = "OPPORTUNITY_LOST"
+	EventTypeOrderPlaced     EventType = "ORDER_PLACED"
+	EventTypeCartAbandoned   EventType = "CART_ABANDONED"
+	EventTypeChatStarted     EventType = "CHAT_STARTED"
+	EventTypeChatFinished    EventType = "CHAT_FINISHED"
+)
+
+const (
+	eventFamilyCDP    = "CDP"
+	defaultFunnelName = "default"
+
+	// maxBatchEvents is the largest number of events accepted by the batch endpoint
+	maxBatchEvents = 25
+)
+
+type ChatSubtype string
+
+const (
+	ChatSubtypeOnline  ChatSubtype = "online"
+	ChatSubtypeOffline ChatSubtype = "offline"
+)
+
+// Event is the raw payload sent to the events API, built by one of the typed events
+type Event struct {
+	EventType   EventType      `json:"event_type"`
+	EventFamily string         `json:"event_family"`
+	Payload     map[string]any `json:"payload"`
+}
+
+// EventBuilder validates a typed event and builds its payload
+type EventBuilder interface {
+	Event() (Event, error)
+}
+
+// ConversionEvent registers a conversion, creating the contact when it doesn't exist
+type ConversionEvent struct {
+	// ConversionIdentifier names the conversion in RD Station, e.g.: "chatbot-lead"
+	ConversionIdentifier string
+	Email                string
+	Name                 string
+	JobTitle             string
+	PersonalPhone        string
+	MobilePhone          string
+	CompanyName          string
+	City                 string
+	State                string
+	Country              string
+	Tags                 []string
+	LegalBases           []rd_station.LegalBasis
+	AvailableForMailing  *bool
+	ClientTrackingID     string
+
+	// TrafficSource, TrafficMedium, TrafficCampaign and TrafficValue map the utm_source,
+	// utm_medium, utm_campaign and utm_term of the lead
+	TrafficSource   string
+	TrafficMedium   string
+	TrafficCampaign string
+	TrafficValue    string
+
+	// CustomFields are sent as "cf_" fields, the prefix is added when missing
+	CustomFields map[string]any
+}
+
+func (e ConversionEvent) Event() (Event, error) {
+	v := eventValidator{}
+	v.required("conversion_identifier", e.ConversionIdentifier)
+	v.email("email", e.Email)
+	if err := v.err(EventTypeConversion); err != nil {
+		return Event{}, err
+	}
+
+	p := payload{}
+	p.set("conversion_identifier", e.ConversionIdentifier)
+	p.set("email", e.Email)
+	p.set("name", e.Name)
+	p.set("job_title", e.JobTitle)
+	p.set("personal_phone", e.PersonalPhone)
+	p.set("mobile_phone", e.MobilePhone)
+	p.set("company_name", e.CompanyName)
+	p.set("city", e.City)
+	p.set("state", e.State)
+	p.set("country", e.Country)
+	p.set("client_tracking_id", e.ClientTrackingID)
+	p.set("traffic_source", e.TrafficSource)
+	p.set("traffic_medium", e.TrafficMedium)
+	p.set("traffic_campaign", e.TrafficCampaign)
+	p.set("traffic_value", e.TrafficValue)
+	if len(e.Tags) > 0 {
+		p["tags"] = e.Tags
+	}
+	if len(e.LegalBases) > 0 {
+		p["legal_bases"] = e.LegalBases
+	}
+	if e.AvailableForMailing != nil {
+		p["available_for_mailing"] = *e.AvailableForMailing
+	}
+	p.customFields(e.CustomFields)
+
+	return newEvent(EventTypeConversion, p), nil
+}
+
+// OpportunityEvent marks the contact as an opportunity in a funnel
+type OpportunityEvent struct {
+	Email string
+	// FunnelName defaults to "default"
+	FunnelName string
+}
+
+func (e OpportunityEvent) Event() (Event, error) {
+	v := eventValidator{}
+	v.email("email", e.Email)
+	if err := v.err(EventTypeOpportunity); err != nil {
+		return Event{}, err
+	}
+
+	return newEvent(EventTypeOpportunity, payload{"email": e.Email, "funnel_name": funnelName(e.FunnelName)}), nil
+}
+
+// SaleEvent marks the contact as a customer in a funnel
+type SaleEvent struct {
+	Email string
+	// FunnelName defaults to "default"
+	FunnelName string
+	Value      float64
+}
+
+func (e SaleEvent) Event() (Event, error) {
+	v := eventValidator{}
+	v.email("email", e.Email)
+	if e.Value < 0 {
+		v.add("value", "must not be negative")
+	}
+	if err := v.err(EventTypeSale); err != nil {
+		return Event{}, err
+	}
+
+	p := payload{"email": e.Email, "funnel_name": funnelName(e.FunnelName)}
+	if e.Value > 0 {
+		p["value"] = e.Value
+	}
+
+	return newEvent(EventTypeSale, p), nil
+}
+
+// OpportunityLostEvent marks the contact opportunity as lost in a funnel
+type OpportunityLostEvent struct {
+	Email string
+	// FunnelName defaults to "default"
+	FunnelName string
+	Reason     string
+}
+
+func (e OpportunityLostEvent) Event() (Event, error) {
+	v := eventValidator{}
+	v.email("email", e.Email)
+	if err := v.err(EventTypeOpportunityLost); err != nil {
+		return Event{}, err
+	}
+
+	p := payload{"email": e.Email, "funnel_name": funnelName(e.FunnelName)}
+	p.set("reason", e.Reason)
+
+	return newEvent(EventTypeOpportunityLost, p), nil
+}
+
+// OrderPlacedEvent registers an ecommerce order
+type OrderPlacedEvent struct {
+	Name          string
+	Email         string
+	OrderID       string
+	TotalItems    int
+	Status        string
+	PaymentMethod string
+	PaymentAmount float64
+	CustomFields  map[string]any
+}
+
+func (e OrderPlacedEvent) Event() (Event, error) {
+	v := eventValidator{}
+	v.email("email", e.Email)
+	v.required("cf_order_id", e.OrderID)
+	if e.TotalItems < 0 {
+		v.add("cf_order_total_items", "must not be negative")
+	}
+	if err := v.err(EventTypeOrderPlaced); err != nil {
+		return Event{}, err
+	}
+
+	p := payload{"email": e.Email, "cf_order_id": e.OrderID, "cf_order_total_items": e.TotalItems}
+	p.set("name", e.Name)
+	p.set("cf_order_status", e.Status)
+	p.set("cf_order_payment_method", e.PaymentMethod)
+	if e.PaymentAmount > 0 {
+		p["cf_order_payment_amount"] = e.PaymentAmount
+	}
+	p.customFields(e.CustomFields)
+
+	return newEvent(EventTypeOrderPlaced, p), nil
+}
+
+// CartAbandonedEvent registers an ecommerce cart left without checkout
+type CartAbandonedEvent struct {
+	Name         string
+	Email        string
+	CartID       string
+	TotalItems   int
+	Status       string
+	CustomFields map[string]any
+}
+
+func (e CartAbandonedEvent) Event() (Event, error) {
+	v := eventValidator{}
+	v.email("email", e.Email)
+	v.required("cf_cart_id", e.CartID)
+	if e.TotalItems < 0 {
+		v.add("cf_cart_total_items", "must not be negative")
+	}
+	if err := v.err(EventTypeCartAbandoned); err != nil {
+		return Event{}, err
+	}
+
+	p := payload{"email": e.Email, "cf_cart_id": e.CartID, "cf_cart_total_items": e.TotalItems}
+	p.set("name", e.Name)
+	p.set("cf_cart_status", e.Status)
+	p.customFields(e.CustomFields)
+
+	return newEvent(EventTypeCartAbandoned, p), nil
+}
+
+// ChatStartedEvent registers the start of a chat with the contact
+type ChatStartedEvent struct {
+	ChatSubtype   ChatSubtype
+	Email         string
+	Name          string
+	MobilePhone   string
+	PersonalPhone string
+	ChatStatus    string
+	ChatType      string
+	CustomFields  map[string]any
+}
+
+func (e ChatStartedEvent) Event() (Event, error) {
+	return e.event(EventTypeChatStarted, "")
+}
+
+// ChatFinishedEvent registers the end of a chat with the contact
+type ChatFinishedEvent struct {
+	ChatStartedEvent
+	Transcript string
+}
+
+func (e ChatFinishedEvent) Event() (Event, error) {
+	return e.event(EventTypeChatFinished, e.Transcript)
+}
+
+func (e ChatStartedEvent) event(eventType EventType, transcript string) (Event, error) {
+	v := eventValidator{}
+	v.email("email", e.Email)
+	if e.ChatSubtype != ChatSubtypeOnline && e.ChatSubtype != ChatSubtypeOffline {
+		v.add("chat_subtype", "must be online or offline")
+	}
+	if err := v.err(eventType); err != nil {
+		return Event{}, err
+	}
+
+	p := payload{"email": e.Email, "chat_subtype": string(e.ChatSubtype)}
+	p.set("name", e.Name)
+	p.set("mobile_phone", e.MobilePhone)
+	p.set("personal_phone", e.PersonalPhone)
+	p.set("cf_chat_status", e.ChatStatus)
+	p.set("cf_chat_type", e.ChatType)
+	p.set("cf_chat_transcript", transcript)
+	p.customFields(e.CustomFields)
+
+	return newEvent(eventType, p), nil
+}
+
+type SendEventResponse struct {
+	EventUUID string `json:"event_uuid"`
+}
+
+// SendEvent validates and sends a single event
+func (s *Client) SendEvent(ctx context.Context, builder EventBuilder) (*SendEventResponse, error) {
+	event, err := builder.Event()
+	if err != nil {
+		return nil, err
+	}
+
+	var responsePayload SendEventResponse
+	if err := s.do(ctx, "send event", event, http.MethodPost, eventsEndpoint, &responsePayload, http.StatusOK, http.StatusCreated, http.StatusAccepted); err != nil {
+		return nil, err
+	}
+
+	return &responsePayload, nil
+}
+
+// SendEvents validates every event before sending anything, then sends them in batches of
+// up to 25 events. When a batch fails, the previous batches were already accepted.
+func (s *Client) SendEvents(ctx context.Context, builders ...EventBuilder) error {
+	events := make([]Event, 0, len(builders))
+	var errs []error
+	for i, builder := range builders {
+		event, err := builder.Event()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("event %d: %w", i, err))
+			continue
+		}
+		events = append(events, event)
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	for start := 0; start < len(events); start += maxBatchEvents {
+		batch := events[start:min(start+maxBatchEvents, len(events))]
+		if err := s.do(ctx, "send events batch", batch, http.MethodPost, batchEventsEndpoint, nil, http.StatusOK, http.StatusCreated, http.StatusAccepted); err != nil {
+			return fmt.Errorf("events %d to %d: %w", start, start+len(batch)-1, err)
+		}
+	}
+
+	return nil
+}
+
+func newEvent(eventType EventType, p payload) Event {
+	return Event{EventType: eventType, EventFamily: eventFamilyCDP, Payload: p}
+}
+
+func funnelName(name string) string {
+	if name == "" {
+		return defaultFunnelName
+	}
+
+	return name
+}
+
+type payload map[string]any
+
+// set skips empty values so optional fields aren't sent
+func (p payload) set(key, value string) {
+	if value != "" {
+		p[key] = value
+	}
+}
+
+func (p payload) customFields(fields map[string]any) {
+	for key, value := range fields {
+		if !strings.HasPrefix(key, customFieldPrefix) {
+			key = customFieldPrefix + key
+		}
+		p[key] = value
+	}
+}
+
+// eventValidator collects every invalid field so callers can fix them all at once
+type eventValidator struct {
+	problems []string
+}
+
+func (v *eventValidator) add(field, problem string) {
+	v.problems = append(v.problems, field+" "+problem)
+}
+
+func (v *eventValidator) required(field, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.add(field, "is required")
+	}
+}
+
+func (v *eventValidator) email(field, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.add(field, "is required")
+		return
+	}
+
+	if address, err := mail.ParseAddress(value); err != nil || address.Address != value {
+		v.add(field, "is not a valid email")
+	}
+}
+
+func (v *eventValidator) err(eventType EventType) error {
+	if len(v.problems) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s: %s", ErrInvalidEvent, eventType, strings.Join(v.problems, ", "))
+}
@@ -0,0 +1,77 @@
+package marketing_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/verbeux-ai/rd-station-go/marketing"
+)
+
+func TestConversionEvent(t *testing.T) {
+	event, err := marketing.ConversionEvent{
+		ConversionIdentifier: "chatbot-lead",
+		Email:                "lead@example.com",
+		TrafficSource:        "google",
+		CustomFields:         map[string]any{"plan": "pro"},
+	}.Event()
+	require.NoError(t, err)
+
+	body, err := json.Marshal(event)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"event_type": "CONVERSION",
+		"event_family": "CDP",
+		"payload": {
+			"conversion_identifier": "chatbot-lead",
+			"email": "lead@example.com",
+			"traffic_source": "google",
+			"cf_plan": "pro"
+		}
+	}`, string(body))
+}
+
+func TestEventValidation(t *testing.T) {
+	_, err := marketing.ConversionEvent{Email: "not an email"}.Event()
+	require.ErrorIs(t, err, marketing.ErrInvalidEvent)
+	assert.Contains(t, err.Error(), "conversion_identifier is required")
+	assert.Contains(t, err.Error(), "email is not a valid email")
+
+	_, err = marketing.ChatFinishedEvent{ChatStartedEvent: marketing.ChatStartedEvent{Email: "lead@example.com"}}.Event()
+	require.ErrorIs(t, err, marketing.ErrInvalidEvent)
+	assert.Contains(t, err.Error(), "CHAT_FINISHED")
+}
+
+func TestSendEventsValidatesBeforeSending(t *testing.T) {
+	var batches [][]marketing.Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var batch []marketing.Event
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&batch))
+		batches = append(batches, batch)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	client := marketing.NewClient(marketing.WithBaseUrl(server.URL), marketing.WithAccessToken("token"))
+
+	err := client.SendEvents(context.Background(),
+		marketing.OpportunityEvent{Email: "lead@example.com"},
+		marketing.SaleEvent{Email: "invalid"},
+	)
+	require.ErrorIs(t, err, marketing.ErrInvalidEvent)
+	assert.Empty(t, batches)
+
+	events := make([]marketing.EventBuilder, 30)
+	for i := range events {
+		events[i] = marketing.OpportunityEvent{Email: "lead@example.com"}
+	}
+	require.NoError(t, client.SendEvents(context.Background(), events...))
+	require.Len(t, batches, 2)
+	assert.Len(t, batches[0], 25)
+	assert.Len(t, batches[1], 5)
+	assert.Equal(t, "default", batches[1][0].Payload["funnel_name"])
+}
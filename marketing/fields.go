@@ -0,0 +1,66 @@
+package marketing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// LocalizedText maps a locale (or "default") to a text, e.g.: {"default": "Plan", "pt-BR": "Plano"}
+type LocalizedText map[string]string
+
+type Field struct {
+	UUID             string         `json:"uuid"`
+	APIIdentifier    string         `json:"api_identifier"`
+	CustomField      bool           `json:"custom_field"`
+	DataType         string         `json:"data_type"`
+	Name             LocalizedText  `json:"name"`
+	Label            LocalizedText  `json:"label"`
+	PresentationType string         `json:"presentation_type"`
+	ValidationRules  map[string]any `json:"validation_rules"`
+}
+
+type ListFieldsResponse struct {
+	Fields []Field `json:"fields"`
+}
+
+type FieldData struct {
+	// APIIdentifier must start with "cf_" and can't be changed after creation
+	APIIdentifier    string         `json:"api_identifier,omitempty"`
+	DataType         string         `json:"data_type,omitempty"`
+	Name             LocalizedText  `json:"name,omitempty"`
+	Label            LocalizedText  `json:"label,omitempty"`
+	PresentationType string         `json:"presentation_type,omitempty"`
+	ValidationRules  map[string]any `json:"validation_rules,omitempty"`
+}
+
+func (s *Client) ListFields(ctx context.Context) (*ListFieldsResponse, error) {
+	var responsePayload ListFieldsResponse
+	if err := s.do(ctx, "list fields", nil, http.MethodGet, listFieldsEndpoint, &responsePayload, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	return &responsePayload, nil
+}
+
+func (s *Client) CreateField(ctx context.Context, field FieldData) (*Field, error) {
+	var responsePayload Field
+	if err := s.do(ctx, "create field", field, http.MethodPost, createFieldEndpoint, &responsePayload, http.StatusOK, http.StatusCreated); err != nil {
+		return nil, err
+	}
+
+	return &responsePayload, nil
+}
+
+func (s *Client) UpdateField(ctx context.Context, fieldUUID string, field FieldData) (*Field, error) {
+	var responsePayload Field
+	if err := s.do(ctx, "update field", field, http.MethodPatch, fmt.Sprintf(fieldByUUIDEndpoint, fieldUUID), &responsePayload, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	return &responsePayload, nil
+}
+
+func (s *Client) DeleteField(ctx context.Context, fieldUUID string) error {
+	return s.do(ctx, "delete field", nil, http.MethodDelete, fmt.Sprintf(fieldByUUIDEndpoint, fieldUUID), nil, http.StatusOK, http.StatusNoContent)
+}
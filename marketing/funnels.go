@@ -0,0 +1,59 @@
+package marketing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+type LifecycleStage string
+
+const (
+	LifecycleStageLead          LifecycleStage = "Lead"
+	LifecycleStageQualifiedLead LifecycleStage = "Qualified Lead"
+	LifecycleStageClient        LifecycleStage = "Client"
+)
+
+type Funnel struct {
+	LifecycleStage    LifecycleStage `json:"lifecycle_stage"`
+	Opportunity       bool           `json:"opportunity"`
+	ContactOwnerEmail *string        `json:"contact_owner_email"`
+	Interactions      int            `json:"interactions"`
+	Fit               string         `json:"fit"`
+	Interest          int            `json:"interest"`
+}
+
+// UpdateFunnelRequest changes the contact position in the default funnel. Nil fields are left unchanged.
+type UpdateFunnelRequest struct {
+	LifecycleStage    *LifecycleStage `json:"lifecycle_stage,omitempty"`
+	Opportunity       *bool           `json:"opportunity,omitempty"`
+	ContactOwnerEmail *string         `json:"contact_owner_email,omitempty"`
+}
+
+func (s *Client) GetContactFunnel(ctx context.Context, identifier ContactIdentifier) (*Funnel, error) {
+	var responsePayload Funnel
+	if err := s.do(ctx, "get contact funnel", nil, http.MethodGet, fmt.Sprintf(contactFunnelEndpoint, identifier.path()), &responsePayload, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	return &responsePayload, nil
+}
+
+func (s *Client) UpdateContactFunnel(ctx context.Context, identifier ContactIdentifier, funnel UpdateFunnelRequest) (*Funnel, error) {
+	var responsePayload Funnel
+	if err := s.do(ctx, "update contact funnel", funnel, http.MethodPut, fmt.Sprintf(contactFunnelEndpoint, identifier.path()), &responsePayload, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	return &responsePayload, nil
+}
+
+// SetLifecycleStage moves the contact to a lifecycle stage of the default funnel
+func (s *Client) SetLifecycleStage(ctx context.Context, identifier ContactIdentifier, stage LifecycleStage) (*Funnel, error) {
+	return s.UpdateContactFunnel(ctx, identifier, UpdateFunnelRequest{LifecycleStage: &stage})
+}
+
+// SetOpportunity marks or unmarks the contact as an opportunity
+func (s *Client) SetOpportunity(ctx context.Context, identifier ContactIdentifier, opportunity bool) (*Funnel, error) {
+	return s.UpdateContactFunnel(ctx, identifier, UpdateFunnelRequest{Opportunity: &opportunity})
+}
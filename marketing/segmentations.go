@@ -0,0 +1,62 @@
+package marketing
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+
+	rd_station "github.com/verbeux-ai/rd-station-go"
+)
+
+// maxPageSize is the largest page size accepted by the paginated endpoints
+const maxPageSize = 125
+
+type Segmentation struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	Standard      bool   `json:"standard"`
+	CreatedAt     string `json:"created_at"`
+	UpdatedAt     string `json:"updated_at"`
+	ProcessStatus string `json:"process_status"`
+	Links         []Link `json:"links"`
+}
+
+type SegmentationContact struct {
+	UUID               string `json:"uuid"`
+	Name               string `json:"name"`
+	Email              string `json:"email"`
+	LastConversionDate string `json:"last_conversion_date"`
+	CreatedAt          string `json:"created_at"`
+	Links              []Link `json:"links"`
+}
+
+type listSegmentationsResponse struct {
+	Segmentations []Segmentation `json:"segmentations"`
+}
+
+type listSegmentationContactsResponse struct {
+	Contacts []SegmentationContact `json:"contacts"`
+}
+
+func (s *Client) ListSegmentations(ctx context.Context) ([]Segmentation, error) {
+	return rd_station.CollectPages(ctx, func(ctx context.Context, page int) ([]Segmentation, bool, error) {
+		var responsePayload listSegmentationsResponse
+		if err := s.do(ctx, "list segmentations", nil, http.MethodGet, fmt.Sprintf(listSegmentationsEndpoint, page, maxPageSize), &responsePayload, http.StatusOK); err != nil {
+			return nil, false, err
+		}
+		return responsePayload.Segmentations, len(responsePayload.Segmentations) == maxPageSize, nil
+	})
+}
+
+// SegmentationContacts iterates over the contacts of a segmentation, fetching pages on demand
+func (s *Client) SegmentationContacts(ctx context.Context, segmentationID string) iter.Seq2[SegmentationContact, error] {
+	return rd_station.Paginate(ctx, func(ctx context.Context, page int) ([]SegmentationContact, bool, error) {
+		var responsePayload listSegmentationContactsResponse
+		if err := s.do(ctx, "list segmentation contacts", nil, http.MethodGet, fmt.Sprintf(listSegmentationContactsEndpoint, url.PathEscape(segmentationID), page, maxPageSize), &responsePayload, http.StatusOK); err != nil {
+			return nil, false, err
+		}
+		return responsePayload.Contacts, len(responsePayload.Contacts) == maxPageSize, nil
+	})
+}
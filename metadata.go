@@ -0,0 +1,128 @@
+package rd_station
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ResponseMetadata describes the HTTP response of an API call
+type ResponseMetadata struct {
+	StatusCode int
+	Header     http.Header
+	// RequestID is the X-Request-Id header, useful when reporting issues to RD Station
+	RequestID string
+	// RateLimitLimit, RateLimitRemaining and RateLimitReset are read from the rate-limit
+	// headers, -1 and the zero time when the API didn't send them
+	RateLimitLimit     int
+	RateLimitRemaining int
+	RateLimitReset     time.Time
+	// RawBody is the undecoded response body
+	RawBody  []byte
+	Duration time.Duration
+	Retries  int
+	// Cached is true when the response was served from the cache
+	Cached bool
+}
+
+// CallOption configures a single API call
+type CallOption func(*callConfig)
+
+type callConfig struct {
+	metadata       *ResponseMetadata
+	skipValidation bool
+}
+
+type callConfigKey struct{}
+
+// CaptureMetadata fills metadata with the response of the call. Methods sending several
+// requests fill it with the last one.
+func CaptureMetadata(metadata *ResponseMetadata) CallOption {
+	return func(c *callConfig) {
+		c.metadata = metadata
+	}
+}
+
+// Call runs an API call capturing its metadata, e.g.:
+//
+//	resp, err := rd_station.Call(func(opt rd_station.CallOption) (*rd_station.GetDealResponse, error) {
+//		return client.GetDeal(ctx, dealID, opt)
+//	})
+//
+// The metadata is returned on errors too, when the API answered.
+func Call[T any](call func(opt CallOption) (T, error)) (Response[T], error) {
+	var response Response[T]
+	data, err := call(CaptureMetadata(&response.Metadata))
+	response.Data = data
+
+	return response, err
+}
+
+// withCallOptions applies the options over the ones already in the context, so the helpers
+// that call other methods with options of their own keep the options of the caller
+func withCallOptions(ctx context.Context, opts []CallOption) context.Context {
+	if len(opts) == 0 {
+		return ctx
+	}
+
+	c := callConfigFromContext(ctx)
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return context.WithValue(ctx, callConfigKey{}, c)
+}
+
+func callConfigFromContext(ctx context.Context) callConfig {
+	c, _ := ctx.Value(callConfigKey{}).(callConfig)
+	return c
+}
+
+// captureMetadata fills the metadata requested for the call, buffering the body so the
+// caller can still decode it
+func captureMetadata(metadata *ResponseMetadata, resp *http.Response, stats *RequestStats, duration time.Duration) error {
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	*metadata = ResponseMetadata{
+		StatusCode:         resp.StatusCode,
+		Header:             resp.Header.Clone(),
+		RequestID:          resp.Header.Get("X-Request-Id"),
+		RateLimitLimit:     headerInt(resp.Header, "X-RateLimit-Limit", "RateLimit-Limit"),
+		RateLimitRemaining: headerInt(resp.Header, "X-RateLimit-Remaining", "RateLimit-Remaining"),
+		RateLimitReset:     rateLimitReset(resp.Header),
+		RawBody:            body,
+		Duration:           duration,
+		Retries:            stats.Retries,
+		Cached:             stats.Cached,
+	}
+
+	return err
+}
+
+func headerInt(header http.Header, names ...string) int {
+	for _, name := range names {
+		if value, err := strconv.Atoi(header.Get(name)); err == nil {
+			return value
+		}
+	}
+
+	return -1
+}
+
+// rateLimitReset reads the reset header, either as seconds until the reset or as a Unix time
+func rateLimitReset(header http.Header) time.Time {
+	value := headerInt(header, "X-RateLimit-Reset", "RateLimit-Reset")
+	switch {
+	case value < 0:
+		return time.Time{}
+	case value > 1_000_000_000:
+		return time.Unix(int64(value), 0)
+	}
+
+	return time.Now().Add(time.Duration(value) * time.Second)
+}
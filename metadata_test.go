@@ -0,0 +1,69 @@
+package rd_station_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rd_station "github.com/verbeux-ai/rd-station-go"
+)
+
+func TestCaptureMetadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-1")
+		w.Header().Set("X-RateLimit-Limit", "120")
+		w.Header().Set("X-RateLimit-Remaining", "119")
+		w.Header().Set("X-RateLimit-Reset", "60")
+		if r.URL.Path == "/api/v1/deals/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"not found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"123","name":"Website"}`))
+	}))
+	defer server.Close()
+
+	client := rd_station.NewClient(rd_station.WithBaseUrl(server.URL))
+	ctx := context.Background()
+
+	var metadata rd_station.ResponseMetadata
+	deal, err := client.GetDeal(ctx, "123", rd_station.CaptureMetadata(&metadata))
+	require.NoError(t, err)
+	assert.Equal(t, "Website", deal.Name)
+
+	assert.Equal(t, http.StatusOK, metadata.StatusCode)
+	assert.Equal(t, "req-1", metadata.RequestID)
+	assert.Equal(t, 120, metadata.RateLimitLimit)
+	assert.Equal(t, 119, metadata.RateLimitRemaining)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), metadata.RateLimitReset, 5*time.Second)
+	assert.JSONEq(t, `{"id":"123","name":"Website"}`, string(metadata.RawBody))
+
+	response, err := rd_station.Call(func(opt rd_station.CallOption) (*rd_station.GetDealResponse, error) {
+		return client.GetDeal(ctx, "missing", opt)
+	})
+	assert.ErrorIs(t, err, rd_station.ErrApiReturnedError)
+	assert.ErrorIs(t, err, rd_station.ErrNotFound)
+	assert.Nil(t, response.Data)
+	assert.Equal(t, http.StatusNotFound, response.Metadata.StatusCode)
+	assert.JSONEq(t, `{"error":"not found"}`, string(response.Metadata.RawBody))
+}
+
+func TestMetadataWithoutRateLimitHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"users":[]}`))
+	}))
+	defer server.Close()
+
+	client := rd_station.NewClient(rd_station.WithBaseUrl(server.URL))
+
+	response, err := rd_station.Call(func(opt rd_station.CallOption) (*rd_station.ListUsersResponse, error) {
+		return client.ListUsers(context.Background(), opt)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, -1, response.Metadata.RateLimitRemaining)
+	assert.True(t, response.Metadata.RateLimitReset.IsZero())
+}
@@ -0,0 +1,220 @@
+package rd_station
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+var errNotReplayable = errors.New("request body can't be replayed")
+
+// Doer sends an HTTP request, as *http.Client does
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc adapts a function to a Doer
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps the Doer that sends the requests of the client. Middlewares see each API
+// call once, around the retries, and never see the credentials, which are added last.
+type Middleware func(next Doer) Doer
+
+// RequestInfo describes the API call a request belongs to
+type RequestInfo struct {
+	// Operation is the name of the client method, e.g.: "CreateDeal"
+	Operation string
+	Method    string
+	// Endpoint is the path of the request without the query string, e.g.: "api/v1/deals/123"
+	Endpoint string
+	// EndpointTemplate is the path without identifiers, e.g.: "api/v1/deals/{id}"
+	EndpointTemplate string
+	// Page is the requested page of list operations, 0 when not paginated
+	Page int
+}
+
+// RequestStats accumulates what happened while sending a request. The retry middleware fills
+// it, so it's complete for the middlewares around the retries once next.Do returns.
+type RequestStats struct {
+	Retries int
+	// RateLimited is the number of 429 responses that were retried
+	RateLimited int
+	// RateLimitWait is the time spent waiting for the rate limiter and after 429 responses
+	RateLimitWait time.Duration
+	// Cached is true when the response was served from the cache
+	Cached bool
+}
+
+type requestInfoKey struct{}
+
+// RequestInfoFromContext returns the call description of a request built by the client,
+// available to middlewares through req.Context()
+func RequestInfoFromContext(ctx context.Context) (RequestInfo, bool) {
+	info, ok := ctx.Value(requestInfoKey{}).(RequestInfo)
+	return info, ok
+}
+
+// OperationFromContext returns the name of the client method that built the request, if any
+func OperationFromContext(ctx context.Context) string {
+	info, _ := RequestInfoFromContext(ctx)
+	return info.Operation
+}
+
+func withRequestInfo(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, info)
+}
+
+type requestStatsKey struct{}
+
+// RequestStatsFromContext returns the stats of a request built by the client, nil otherwise
+func RequestStatsFromContext(ctx context.Context) *RequestStats {
+	stats, _ := ctx.Value(requestStatsKey{}).(*RequestStats)
+	return stats
+}
+
+func withRequestStats(ctx context.Context, stats *RequestStats) context.Context {
+	return context.WithValue(ctx, requestStatsKey{}, stats)
+}
+
+// WithMiddleware adds middlewares to the client. The first middleware is the outermost one.
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(c *Client) {
+		c.middlewares = append(c.middlewares, middlewares...)
+	}
+}
+
+// RetryMiddleware retries requests according to the policy. The request body must be
+// replayable through req.GetBody, as it is for the requests built by the client.
+func RetryMiddleware(policy RetryPolicy) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			attempt := 0
+			return policy.Do(req.Context(), req.Method, func() (*http.Response, error) {
+				attempt++
+				if attempt == 1 {
+					return next.Do(req)
+				}
+
+				retry, err := replayRequest(req)
+				if err != nil {
+					return nil, err
+				}
+				return next.Do(retry)
+			})
+		})
+	}
+}
+
+// buildDoer chains the middlewares around the cache, the logging, the circuit breaker, the
+// retries, the rate limiter and the authenticated http client
+func (s *Client) buildDoer() Doer {
+	doer := Doer(DoerFunc(s.authenticatedDo))
+	if s.rateLimiter != nil {
+		doer = RateLimitMiddleware(s.rateLimiter)(doer)
+	}
+
+	if s.retryPolicy.MaxAttempts > 1 {
+		doer = RetryMiddleware(s.retryPolicy)(doer)
+	}
+
+	if s.circuitBreaker != nil {
+		doer = s.circuitBreaker(doer)
+	}
+
+	if s.logger != nil {
+		doer = LoggingMiddleware(s.logger, s.logOptions)(doer)
+	}
+
+	if s.cache != nil {
+		doer = s.cache.Middleware()(doer)
+	}
+
+	for i := len(s.middlewares) - 1; i >= 0; i-- {
+		doer = s.middlewares[i](doer)
+	}
+
+	return doer
+}
+
+// authenticatedDo adds the credentials to a copy of the request and sends it, refreshing
+// the credentials and sending it again once when they are rejected. The credentials are
+// redacted from errors and the response points to the original request, so they don't
+// leak to the middlewares.
+func (s *Client) authenticatedDo(req *http.Request) (*http.Response, error) {
+	resp, err := s.sendAuthenticated(req)
+	if resp != nil {
+		resp.Request = req
+	}
+
+	return resp, redactError(err)
+}
+
+func (s *Client) sendAuthenticated(req *http.Request) (*http.Response, error) {
+	if s.authenticator == nil {
+		return s.httpClient.Do(req)
+	}
+
+	authenticated := req.Clone(req.Context())
+	if err := s.authenticator.Authenticate(req.Context(), authenticated); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrAuthentication, err)
+	}
+
+	resp, err := s.httpClient.Do(authenticated)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	refreshable, ok := s.authenticator.(RefreshableAuthenticator)
+	if !ok {
+		return resp, nil
+	}
+
+	if err := refreshable.Refresh(req.Context(), authenticated); err != nil {
+		// keep the 401 so the caller reports the original api error
+		return resp, nil
+	}
+
+	retry, err := replayRequest(req)
+	if err != nil {
+		return resp, nil
+	}
+	drainAndClose(resp)
+
+	if err := s.authenticator.Authenticate(req.Context(), retry); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrAuthentication, err)
+	}
+
+	return s.httpClient.Do(retry)
+}
+
+// replayRequest copies a request that was already sent, with a fresh body
+func replayRequest(req *http.Request) (*http.Request, error) {
+	replay := req.Clone(req.Context())
+	if req.Body == nil || req.Body == http.NoBody {
+		return replay, nil
+	}
+
+	if req.GetBody == nil {
+		return nil, errNotReplayable
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	replay.Body = body
+
+	return replay, nil
+}
+
+func drainAndClose(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+}
@@ -0,0 +1,47 @@
+package rd_station_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rd_station "github.com/verbeux-ai/rd-station-go"
+)
+
+func TestMiddlewareChain(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "abc", r.Header.Get("X-Trace-Id"))
+		assert.Equal(t, "secret", r.URL.Query().Get("token"))
+		_, _ = w.Write([]byte(`{"id":"123"}`))
+	}))
+	defer server.Close()
+
+	var calls []string
+	record := func(name string) rd_station.Middleware {
+		return func(next rd_station.Doer) rd_station.Doer {
+			return rd_station.DoerFunc(func(req *http.Request) (*http.Response, error) {
+				info, ok := rd_station.RequestInfoFromContext(req.Context())
+				require.True(t, ok)
+				assert.False(t, req.URL.Query().Has("token"), "middlewares must not see the token")
+
+				calls = append(calls, name+":"+info.Operation+":"+info.Endpoint)
+				req.Header.Set("X-Trace-Id", "abc")
+				return next.Do(req)
+			})
+		}
+	}
+
+	client := rd_station.NewClient(
+		rd_station.WithBaseUrl(server.URL),
+		rd_station.WithToken("secret"),
+		rd_station.WithMiddleware(record("outer"), record("inner")),
+	)
+
+	deal, err := client.GetDeal(context.Background(), "123")
+	require.NoError(t, err)
+	assert.Equal(t, "123", deal.ID)
+	assert.Equal(t, []string{"outer:GetDeal:api/v1/deals/123", "inner:GetDeal:api/v1/deals/123"}, calls)
+}
@@ -0,0 +1,251 @@
+package rd_station
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+)
+
+var (
+	ErrUnsetOptional = errors.New("unset optional field can't be encoded")
+)
+
+type optionalState uint8
+
+const (
+	optionalUnset optionalState = iota
+	optionalNull
+	optionalValue
+)
+
+// Optional is a field of an update payload that is either unset, and left out of the
+// request, null, clearing the value on the API, or set to a value. The zero value is unset.
+//
+// Only the payloads of this package and structs tagging the field with omitzero leave unset
+// fields out. Anywhere else, encoding an unset field fails with ErrUnsetOptional instead of
+// sending a null that would clear the value.
+//
+//	rd_station.UpdateContactData{
+//		Title:          rd_station.Set("CEO"),
+//		OrganizationID: rd_station.Null[string](),
+//		Phones:         rd_station.Set([]rd_station.Phone{}),
+//	}
+type Optional[T any] struct {
+	value T
+	state optionalState
+}
+
+// Set returns an Optional holding the value
+func Set[T any](value T) Optional[T] {
+	return Optional[T]{value: value, state: optionalValue}
+}
+
+// Null returns an Optional that clears the field
+func Null[T any]() Optional[T] {
+	return Optional[T]{state: optionalNull}
+}
+
+// FromPtr returns an unset Optional for nil and one holding the pointed value otherwise
+func FromPtr[T any](value *T) Optional[T] {
+	if value == nil {
+		return Optional[T]{}
+	}
+
+	return Set(*value)
+}
+
+// IsSet reports whether the field is sent, either null or with a value
+func (o Optional[T]) IsSet() bool {
+	return o.state != optionalUnset
+}
+
+// IsNull reports whether the field clears the value
+func (o Optional[T]) IsNull() bool {
+	return o.state == optionalNull
+}
+
+// Get returns the value and whether there is one
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.state == optionalValue
+}
+
+// IsZero reports whether the field is unset, so it's left out by the payloads and by omitzero
+func (o Optional[T]) IsZero() bool {
+	return o.state == optionalUnset
+}
+
+func (o Optional[T]) optional() {}
+
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	switch o.state {
+	case optionalUnset:
+		return nil, ErrUnsetOptional
+	case optionalNull:
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(o.value)
+}
+
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*o = Null[T]()
+		return nil
+	}
+
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*o = Set(value)
+
+	return nil
+}
+
+type optionalField interface {
+	IsZero() bool
+	optional()
+}
+
+// marshalPartial encodes a struct as encoding/json does, leaving out the unset Optional fields
+func marshalPartial(value any) ([]byte, error) {
+	fields := appendPartialFields(nil, reflect.ValueOf(value), 0)
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+
+	first := true
+	for _, field := range fields {
+		if field.omit || field.shadowed {
+			continue
+		}
+
+		key, err := json.Marshal(field.name)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := json.Marshal(field.value.Interface())
+		if err != nil {
+			return nil, err
+		}
+
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(data)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+type partialField struct {
+	name  string
+	value reflect.Value
+	depth int
+	// omit is set for unset and omitempty fields, shadowed for the fields of embedded structs
+	// hidden by a shallower field with the same name
+	omit     bool
+	shadowed bool
+}
+
+// appendPartialFields collects the fields of a struct, promoting the fields of the untagged
+// embedded structs as encoding/json does
+func appendPartialFields(fields []partialField, v reflect.Value, depth int) []partialField {
+	t := v.Type()
+	for i := range t.NumField() {
+		fieldType := t.Field(i)
+		tag := fieldType.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+		field := v.Field(i)
+
+		if fieldType.Anonymous && name == "" {
+			embedded := field
+			if embedded.Kind() == reflect.Pointer && embedded.Type().Elem().Kind() == reflect.Struct {
+				if embedded.IsNil() {
+					continue
+				}
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				fields = appendPartialFields(fields, embedded, depth+1)
+				continue
+			}
+		}
+
+		if !fieldType.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = fieldType.Name
+		}
+
+		omit := strings.Contains(","+options+",", ",omitempty,") && isEmptyValue(field) ||
+			strings.Contains(","+options+",", ",omitzero,") && isZeroValue(field)
+		if optional, ok := field.Interface().(optionalField); ok && optional.IsZero() {
+			omit = true
+		}
+
+		fields = append(fields, partialField{name: name, value: field, depth: depth, omit: omit})
+	}
+
+	if depth > 0 {
+		return fields
+	}
+
+	// the shallowest field wins, and the first one among fields of the same depth
+	winners := map[string]int{}
+	for i, field := range fields {
+		winner, ok := winners[field.name]
+		if !ok || field.depth < fields[winner].depth {
+			if ok {
+				fields[winner].shadowed = true
+			}
+			winners[field.name] = i
+			continue
+		}
+		fields[i].shadowed = true
+	}
+
+	return fields
+}
+
+// isZeroValue matches the omitzero rules of encoding/json
+func isZeroValue(v reflect.Value) bool {
+	if v.Kind() == reflect.Pointer && v.IsNil() {
+		return true
+	}
+	if zeroer, ok := v.Interface().(interface{ IsZero() bool }); ok {
+		return zeroer.IsZero()
+	}
+
+	return v.IsZero()
+}
+
+// isEmptyValue matches the omitempty rules of encoding/json
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	}
+
+	return false
+}
@@ -0,0 +1,105 @@
+package rd_station_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rd_station "github.com/verbeux-ai/rd-station-go"
+)
+
+func TestUpdatePayloadsSkipAndClearFields(t *testing.T) {
+	contact := rd_station.UpdateContactRequest{
+		Contact: rd_station.UpdateContactData{
+			Name:           rd_station.Set("Ana"),
+			Title:          rd_station.Null[string](),
+			OrganizationID: rd_station.Null[string](),
+			Phones:         rd_station.Set([]rd_station.Phone{}),
+		},
+	}
+
+	data, err := json.Marshal(contact)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"contact":{"name":"Ana","organization_id":null,"phones":[],"title":null}}`, string(data))
+
+	deal := rd_station.UpdateDealRequest{
+		Deal: rd_station.UpdateDealRequestData{
+			PredictionDate: rd_station.Null[string](),
+			Rating:         rd_station.Set(0.0),
+			DealSource:     rd_station.Set(rd_station.UpdateDealSourceRequestData{ID: rd_station.Set("site")}),
+		},
+	}
+
+	data, err = json.Marshal(deal)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"deal":{"prediction_date":null,"rating":0,"deal_source":{"_id":"site"}}}`, string(data))
+}
+
+func TestOptional(t *testing.T) {
+	var unset rd_station.Optional[string]
+	assert.False(t, unset.IsSet())
+	assert.True(t, unset.IsZero())
+
+	null := rd_station.Null[string]()
+	assert.True(t, null.IsSet())
+	assert.True(t, null.IsNull())
+
+	value, ok := rd_station.Set("x").Get()
+	assert.True(t, ok)
+	assert.Equal(t, "x", value)
+
+	assert.False(t, rd_station.FromPtr[string](nil).IsSet())
+
+	var decoded struct {
+		Title rd_station.Optional[string] `json:"title"`
+		Skype rd_station.Optional[string] `json:"skype"`
+		Name  rd_station.Optional[string] `json:"name"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"title":null,"name":"Ana"}`), &decoded))
+	assert.True(t, decoded.Title.IsNull())
+	assert.False(t, decoded.Skype.IsSet())
+	name, _ := decoded.Name.Get()
+	assert.Equal(t, "Ana", name)
+}
+
+func TestUnsetOptionalOutsidePayloads(t *testing.T) {
+	_, err := json.Marshal(struct {
+		Title rd_station.Optional[string] `json:"title"`
+	}{})
+	assert.ErrorIs(t, err, rd_station.ErrUnsetOptional)
+
+	data, err := json.Marshal(struct {
+		Title rd_station.Optional[string] `json:"title,omitzero"`
+		Name  rd_station.Optional[string] `json:"name"`
+	}{Name: rd_station.Null[string]()})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":null}`, string(data))
+}
+
+func TestMarshalPartialPromotesEmbeddedFields(t *testing.T) {
+	type Base struct {
+		Name  rd_station.Optional[string] `json:"name"`
+		Title rd_station.Optional[string] `json:"title"`
+	}
+	type audit struct {
+		Source string `json:"source,omitempty"`
+	}
+	type Payload struct {
+		Base
+		*audit
+		Title rd_station.Optional[string] `json:"title"`
+		Extra Base                        `json:"extra,omitzero"`
+	}
+
+	data, err := rd_station.MarshalPartial(Payload{
+		Base:  Base{Name: rd_station.Set("Ana"), Title: rd_station.Set("shadowed")},
+		audit: &audit{Source: "import"},
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"Ana","source":"import"}`, string(data))
+
+	data, err = rd_station.MarshalPartial(Payload{Title: rd_station.Null[string]()})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"title":null}`, string(data))
+}
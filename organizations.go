@@ -0,0 +1,25 @@
+package rd_station
+
+import "context"
+
+// Organization is a company of the CRM, to which contacts and deals belong
+type Organization struct {
+	ID                       string               `json:"id"`
+	InternalID               string               `json:"_id"`
+	Name                     string               `json:"name"`
+	Resume                   string               `json:"resume"`
+	URL                      string               `json:"url"`
+	User                     *User                `json:"user"`
+	OrganizationSegments     []interface{}        `json:"organization_segments"`
+	OrganizationCustomFields []ContactCustomField `json:"organization_custom_fields"`
+	CreatedAt                string               `json:"created_at"`
+	UpdatedAt                string               `json:"updated_at"`
+}
+
+func (s *organizationsService) Get(ctx context.Context, organizationID string, opts ...CallOption) (*Organization, error) {
+	return do[Organization](withCallOptions(ctx, opts), s.client, getOrganizationByIDEndpoint, nil, organizationID)
+}
+
+func (s *Client) GetOrganization(ctx context.Context, organizationID string, opts ...CallOption) (*Organization, error) {
+	return s.Organizations.Get(ctx, organizationID, opts...)
+}
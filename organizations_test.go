@@ -0,0 +1,34 @@
+package rd_station_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rd_station "github.com/verbeux-ai/rd-station-go"
+)
+
+func TestGetOrganization(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/organizations/org-1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"org-1","name":"Acme","url":"acme.com","organization_custom_fields":[{"custom_field_id":"cnpj","value":"00.000.000/0001-00"}]}`))
+	}))
+	defer server.Close()
+
+	client := rd_station.NewClient(rd_station.WithBaseUrl(server.URL))
+	ctx := context.Background()
+
+	organization, err := client.GetOrganization(ctx, "org-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Acme", organization.Name)
+	assert.Equal(t, "cnpj", organization.OrganizationCustomFields[0].CustomFieldID)
+
+	_, err = client.GetOrganization(ctx, "missing")
+	assert.ErrorIs(t, err, rd_station.ErrNotFound)
+}
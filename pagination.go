@@ -0,0 +1,46 @@
+package rd_station
+
+import (
+	"context"
+	"iter"
+)
+
+// PageFunc fetches a page of results, starting at page 1, and reports whether there are more pages
+type PageFunc[T any] func(ctx context.Context, page int) (items []T, hasMore bool, err error)
+
+// Paginate iterates over every item of every page, stopping at the first error
+func Paginate[T any](ctx context.Context, fetch PageFunc[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for page := 1; ; page++ {
+			items, hasMore, err := fetch(ctx, page)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if !hasMore || len(items) == 0 {
+				return
+			}
+		}
+	}
+}
+
+// CollectPages fetches every page and returns all items
+func CollectPages[T any](ctx context.Context, fetch PageFunc[T]) ([]T, error) {
+	var all []T
+	for item, err := range Paginate(ctx, fetch) {
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, item)
+	}
+
+	return all, nil
+}
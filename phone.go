@@ -0,0 +1,296 @@
+package rd_station
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrInvalidPhone = errors.New("invalid phone number")
+)
+
+const brazilCountryCode = "55"
+
+// PhoneNormalizer converts free-form phone numbers into the E.164 format (e.g. "+5511987654321").
+// The zero value only handles numbers that already carry a country code; use DefaultPhoneNormalizer
+// for the Brazilian defaults.
+type PhoneNormalizer struct {
+	// DefaultCountryCode is used when the number does not carry a country code, e.g.: "55"
+	DefaultCountryCode string
+
+	// DefaultAreaCode is used for Brazilian numbers typed without the DDD, e.g.: "11"
+	DefaultAreaCode string
+
+	// AddNinthDigit when true prepends the ninth digit to 8-digit Brazilian mobile numbers,
+	// which is how WhatsApp usually reports numbers registered before the ninth digit rollout
+	AddNinthDigit bool
+}
+
+// DefaultPhoneNormalizer returns a normalizer with Brazilian defaults
+func DefaultPhoneNormalizer() PhoneNormalizer {
+	return PhoneNormalizer{
+		DefaultCountryCode: brazilCountryCode,
+		AddNinthDigit:      true,
+	}
+}
+
+// ParsedPhone is a phone number split into its components
+type ParsedPhone struct {
+	// CountryCode is empty for non-Brazilian numbers, which are kept whole in Number
+	CountryCode string
+	// AreaCode is the DDD for Brazilian numbers, empty for other countries
+	AreaCode string
+	// Number is the subscriber number, or every digit of the number for non-Brazilian numbers
+	Number string
+	Mobile bool
+}
+
+// E164 returns the number in the E.164 format, e.g.: "+5511987654321"
+func (p ParsedPhone) E164() string {
+	return "+" + p.CountryCode + p.AreaCode + p.Number
+}
+
+// National returns the number without the country code, e.g.: "11987654321"
+func (p ParsedPhone) National() string {
+	return p.AreaCode + p.Number
+}
+
+// Variants returns the formats the same number may have been stored with, most specific first.
+// For Brazilian mobile numbers it includes the forms with and without the ninth digit.
+func (p ParsedPhone) Variants() []string {
+	numbers := []string{p.Number}
+	if p.CountryCode == brazilCountryCode && p.Mobile {
+		switch len(p.Number) {
+		case 9:
+			numbers = append(numbers, p.Number[1:])
+		case 8:
+			numbers = append(numbers, "9"+p.Number)
+		}
+	}
+
+	var variants []string
+	for _, number := range numbers {
+		national := p.AreaCode + number
+		variants = append(variants, "+"+p.CountryCode+national, p.CountryCode+national)
+		if p.AreaCode != "" {
+			variants = append(variants, national)
+		}
+	}
+
+	return variants
+}
+
+// Normalize returns the phone number in the E.164 format
+func (n PhoneNormalizer) Normalize(raw string) (string, error) {
+	parsed, err := n.Parse(raw)
+	if err != nil {
+		return "", err
+	}
+
+	return parsed.E164(), nil
+}
+
+// Parse splits a free-form phone number into its components, inferring the country and area
+// codes from the normalizer defaults when they are missing
+func (n PhoneNormalizer) Parse(raw string) (ParsedPhone, error) {
+	trimmed := strings.TrimSpace(raw)
+	international := strings.HasPrefix(trimmed, "+")
+	digits := onlyDigits(trimmed)
+
+	if strings.HasPrefix(digits, "00") {
+		international = true
+		digits = digits[2:]
+	}
+
+	if digits == "" {
+		return ParsedPhone{}, fmt.Errorf("%w: %q has no digits", ErrInvalidPhone, raw)
+	}
+
+	if !international {
+		switch n.DefaultCountryCode {
+		case "":
+			// without a default we can only assume the country code was typed without the "+"
+		case brazilCountryCode:
+			national, ok := n.brazilianNational(digits)
+			if !ok {
+				return ParsedPhone{}, fmt.Errorf("%w: %q is not a valid brazilian number", ErrInvalidPhone, raw)
+			}
+			digits = brazilCountryCode + national
+		default:
+			digits = n.DefaultCountryCode + strings.TrimPrefix(digits, "0")
+		}
+	}
+
+	if len(digits) < 8 || len(digits) > 15 {
+		return ParsedPhone{}, fmt.Errorf("%w: %q has an invalid length", ErrInvalidPhone, raw)
+	}
+
+	if !strings.HasPrefix(digits, brazilCountryCode) {
+		// we can't split foreign numbers without their numbering plan, so keep them whole
+		if digits[0] == '0' {
+			return ParsedPhone{}, fmt.Errorf("%w: %q has an invalid country code", ErrInvalidPhone, raw)
+		}
+		return ParsedPhone{Number: digits}, nil
+	}
+
+	return n.parseBrazilian(raw, digits[len(brazilCountryCode):])
+}
+
+// brazilianNational strips trunk and carrier prefixes from a number typed without the
+// country code and returns it with the area code in front
+func (n PhoneNormalizer) brazilianNational(digits string) (string, bool) {
+	if strings.HasPrefix(digits, "0") {
+		digits = digits[1:]
+		// 0 + carrier code (2 digits) + DDD + number, e.g.: "0 21 11 98765-4321"
+		if len(digits) == 12 || len(digits) == 13 {
+			digits = digits[2:]
+		}
+	}
+
+	switch len(digits) {
+	case 8, 9:
+		if n.DefaultAreaCode == "" {
+			return "", false
+		}
+		return n.DefaultAreaCode + digits, true
+	case 10, 11:
+		return digits, true
+	case 12, 13:
+		if strings.HasPrefix(digits, brazilCountryCode) {
+			return digits[len(brazilCountryCode):], true
+		}
+	}
+
+	return "", false
+}
+
+func (n PhoneNormalizer) parseBrazilian(raw, national string) (ParsedPhone, error) {
+	if len(national) != 10 && len(national) != 11 {
+		return ParsedPhone{}, fmt.Errorf("%w: %q is not a valid brazilian number", ErrInvalidPhone, raw)
+	}
+
+	areaCode, number := national[:2], national[2:]
+	if areaCode[0] == '0' || areaCode[1] == '0' {
+		return ParsedPhone{}, fmt.Errorf("%w: %q has an invalid area code", ErrInvalidPhone, raw)
+	}
+
+	parsed := ParsedPhone{CountryCode: brazilCountryCode, AreaCode: areaCode, Number: number}
+	switch {
+	case len(number) == 9 && number[0] == '9':
+		parsed.Mobile = true
+	case len(number) == 9:
+		return ParsedPhone{}, fmt.Errorf("%w: %q has 9 digits but is not a mobile number", ErrInvalidPhone, raw)
+	case number[0] >= '6':
+		parsed.Mobile = true
+		if n.AddNinthDigit {
+			parsed.Number = "9" + number
+		}
+	}
+
+	return parsed, nil
+}
+
+// NormalizePhone normalizes a phone number to the E.164 format using the Brazilian defaults
+func NormalizePhone(raw string) (string, error) {
+	return DefaultPhoneNormalizer().Normalize(raw)
+}
+
+// ParseWhatsApp parses WhatsAppFullInternacional into its components
+func (p Phone) ParseWhatsApp() (ParsedPhone, error) {
+	if p.WhatsAppFullInternacional == "" {
+		return ParsedPhone{}, fmt.Errorf("%w: phone has no whatsapp number", ErrInvalidPhone)
+	}
+
+	normalizer := DefaultPhoneNormalizer()
+	normalizer.AddNinthDigit = false
+
+	return normalizer.Parse("+" + strings.TrimPrefix(p.WhatsAppFullInternacional, "+"))
+}
+
+func onlyDigits(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+func (s *contactsService) FindByWhatsApp(ctx context.Context, number string, opts ...CallOption) ([]Contact, error) {
+	ctx = withCallOptions(ctx, opts)
+
+	normalizer := DefaultPhoneNormalizer()
+	if s.client.phoneNormalizer != nil {
+		normalizer = *s.client.phoneNormalizer
+	}
+
+	parsed, err := normalizer.Parse(number)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	var contacts []Contact
+	for _, variant := range parsed.Variants() {
+		response, err := s.client.listContacts(ctx, ListContactsFilterRequest{Phone: variant})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, contact := range response.Contacts {
+			if seen[contact.ID] {
+				continue
+			}
+			seen[contact.ID] = true
+			contacts = append(contacts, contact)
+		}
+	}
+
+	return contacts, nil
+}
+
+// FindContactsByWhatsApp looks up contacts by an incoming WhatsApp number, trying every format the
+// number may have been stored with (with and without country code and ninth digit)
+func (s *Client) FindContactsByWhatsApp(ctx context.Context, number string, opts ...CallOption) ([]Contact, error) {
+	return s.Contacts.FindByWhatsApp(ctx, number, opts...)
+}
+
+func (s *Client) normalizePhone(raw string) (string, error) {
+	if s.phoneNormalizer == nil {
+		return raw, nil
+	}
+
+	return s.phoneNormalizer.Normalize(raw)
+}
+
+func (s *Client) normalizePhoneData(phones []PhoneData) ([]PhoneData, error) {
+	normalized := make([]PhoneData, len(phones))
+	for i, phone := range phones {
+		number, err := s.normalizePhone(phone.Phone)
+		if err != nil {
+			return nil, err
+		}
+		phone.Phone = number
+		normalized[i] = phone
+	}
+
+	return normalized, nil
+}
+
+func (s *Client) normalizePhones(phones []Phone) ([]Phone, error) {
+	normalized := make([]Phone, len(phones))
+	for i, phone := range phones {
+		number, err := s.normalizePhone(phone.Phone)
+		if err != nil {
+			return nil, err
+		}
+		phone.Phone = number
+		normalized[i] = phone
+	}
+
+	return normalized, nil
+}
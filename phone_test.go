@@ -0,0 +1,61 @@
+package rd_station_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rd_station "github.com/verbeux-ai/rd-station-go"
+)
+
+func TestNormalizePhone(t *testing.T) {
+	cases := map[string]string{
+		"+55 (11) 98765-4321": "+5511987654321",
+		"5511987654321":       "+5511987654321",
+		"(11) 98765-4321":     "+5511987654321",
+		"11 8765-4321":        "+5511987654321",
+		"011 98765-4321":      "+5511987654321",
+		"0 21 11 98765-4321":  "+5511987654321",
+		"0055 11 98765-4321":  "+5511987654321",
+		"(11) 3333-4444":      "+551133334444",
+		"+1 415 555 2671":     "+14155552671",
+	}
+
+	for raw, expected := range cases {
+		normalized, err := rd_station.NormalizePhone(raw)
+		require.NoError(t, err, raw)
+		assert.Equal(t, expected, normalized, raw)
+	}
+}
+
+func TestNormalizePhoneInvalid(t *testing.T) {
+	for _, raw := range []string{"", "abc", "98765-4321", "(10) 98765-4321", "(11) 88765-4321"} {
+		_, err := rd_station.NormalizePhone(raw)
+		assert.ErrorIs(t, err, rd_station.ErrInvalidPhone, raw)
+	}
+}
+
+func TestNormalizePhoneDefaultAreaCode(t *testing.T) {
+	normalizer := rd_station.DefaultPhoneNormalizer()
+	normalizer.DefaultAreaCode = "21"
+
+	normalized, err := normalizer.Normalize("98765-4321")
+	require.NoError(t, err)
+	assert.Equal(t, "+5521987654321", normalized)
+}
+
+func TestPhoneParseWhatsApp(t *testing.T) {
+	phone := rd_station.Phone{WhatsAppFullInternacional: "551187654321"}
+
+	parsed, err := phone.ParseWhatsApp()
+	require.NoError(t, err)
+	assert.Equal(t, "55", parsed.CountryCode)
+	assert.Equal(t, "11", parsed.AreaCode)
+	assert.Equal(t, "87654321", parsed.Number)
+	assert.True(t, parsed.Mobile)
+
+	assert.Equal(t, []string{
+		"+551187654321", "551187654321", "1187654321",
+		"+5511987654321", "5511987654321", "11987654321",
+	}, parsed.Variants())
+}
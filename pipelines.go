@@ -0,0 +1,94 @@
+package rd_station
+
+import "context"
+
+type DealPipeline struct {
+	ID         string              `json:"id"`
+	Name       string              `json:"name"`
+	DealStages []DealPipelineStage `json:"deal_stages"`
+}
+
+type DealPipelineStage struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Nickname  string `json:"nickname"`
+	Order     int    `json:"order"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+func (s *pipelinesService) List(ctx context.Context, opts ...CallOption) ([]DealPipeline, error) {
+	pipelines, err := do[[]DealPipeline](withCallOptions(ctx, opts), s.client, listDealPipelinesEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return *pipelines, nil
+}
+
+func (s *Client) ListDealPipelines(ctx context.Context, opts ...CallOption) ([]DealPipeline, error) {
+	return s.Pipelines.List(ctx, opts...)
+}
+
+type ListDealStagesFilterRequest struct {
+	Page           string `form:"page,omitempty" query:"page"`
+	Limit          string `form:"limit,omitempty" query:"limit"`
+	DealPipelineID string `form:"deal_pipeline_id,omitempty" query:"deal_pipeline_id"`
+}
+
+type ListDealStagesFilterResponse struct {
+	DealStages []DealStageResponse `json:"deal_stages"`
+	HasMore    bool                `json:"has_more"`
+	Total      int                 `json:"total"`
+}
+
+func (s *pipelinesService) ListStages(ctx context.Context, filter ListDealStagesFilterRequest, opts ...CallOption) (*ListDealStagesFilterResponse, error) {
+	return do[ListDealStagesFilterResponse](withCallOptions(ctx, opts), s.client, listDealStagesEndpoint, filter)
+}
+
+func (s *Client) ListDealStagesFilter(ctx context.Context, filter ListDealStagesFilterRequest, opts ...CallOption) (*ListDealStagesFilterResponse, error) {
+	return s.Pipelines.ListStages(ctx, filter, opts...)
+}
+
+type DealLostReason struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type ListDealLostReasonsResponse struct {
+	DealLostReasons []DealLostReason `json:"deal_lost_reasons"`
+}
+
+func (s *pipelinesService) ListLostReasons(ctx context.Context, opts ...CallOption) (*ListDealLostReasonsResponse, error) {
+	return do[ListDealLostReasonsResponse](withCallOptions(ctx, opts), s.client, listDealLostReasonsEndpoint, nil)
+}
+
+func (s *Client) ListDealLostReasons(ctx context.Context, opts ...CallOption) (*ListDealLostReasonsResponse, error) {
+	return s.Pipelines.ListLostReasons(ctx, opts...)
+}
+
+type DealLostReasonRequest struct {
+	DealLostReason DealLostReasonRequestData `json:"deal_lost_reason"`
+}
+
+type DealLostReasonRequestData struct {
+	Name string `json:"name"`
+}
+
+func (s *pipelinesService) CreateLostReason(ctx context.Context, reason DealLostReasonRequest, opts ...CallOption) (*DealLostReason, error) {
+	return do[DealLostReason](withCallOptions(ctx, opts), s.client, createDealLostReasonEndpoint, reason)
+}
+
+func (s *Client) CreateDealLostReason(ctx context.Context, reason DealLostReasonRequest, opts ...CallOption) (*DealLostReason, error) {
+	return s.Pipelines.CreateLostReason(ctx, reason, opts...)
+}
+
+func (s *pipelinesService) UpdateLostReason(ctx context.Context, reasonID string, reason DealLostReasonRequest, opts ...CallOption) (*DealLostReason, error) {
+	return do[DealLostReason](withCallOptions(ctx, opts), s.client, updateDealLostReasonByIDEndpoint, reason, reasonID)
+}
+
+func (s *Client) UpdateDealLostReason(ctx context.Context, reasonID string, reason DealLostReasonRequest, opts ...CallOption) (*DealLostReason, error) {
+	return s.Pipelines.UpdateLostReason(ctx, reasonID, reason, opts...)
+}
@@ -0,0 +1,253 @@
+package rd_station
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// PoolOptions configures a ClientPool, zero values disable the optional features
+type PoolOptions struct {
+	// ClientOptions are applied to every tenant client, e.g.: WithRetryPolicy, WithLogger.
+	// A cache set with WithCache is shared, each tenant gets its own namespace inside it.
+	ClientOptions []Option
+	// Transport is shared by all the tenant clients, a clone of http.DefaultTransport by default
+	Transport http.RoundTripper
+	// Timeout is the timeout of the shared http client
+	Timeout time.Duration
+	// RateLimit is the number of requests per second allowed for each tenant
+	RateLimit float64
+	// Burst is the number of requests a tenant can send at once, 1 by default
+	Burst int
+	// CircuitBreaker enables a circuit breaker per tenant, named after the tenant ID
+	CircuitBreaker *CircuitBreakerSettings
+	// IdleTimeout evicts the tenants that weren't handed out nor sent requests for longer,
+	// tenants with requests in flight are never evicted
+	IdleTimeout time.Duration
+}
+
+// ClientPool hands out one Client per tenant, created on first use. The clients share a
+// single http.Transport, so connections are reused across tenants, while the rate limiter
+// and circuit breaker of each tenant survive between calls and token rotations.
+type ClientPool struct {
+	opts       PoolOptions
+	httpClient *http.Client
+
+	mu      sync.Mutex
+	tenants map[string]*poolTenant
+
+	stop      chan struct{}
+	closeOnce sync.Once
+}
+
+type poolTenant struct {
+	client  *Client
+	token   *rotatingTokenAuthenticator
+	breaker *CircuitBreaker
+
+	// lastUsed is in Unix nanoseconds, updated by the requests of clients held by the callers
+	lastUsed atomic.Int64
+	inflight atomic.Int64
+}
+
+func (t *poolTenant) touch() {
+	t.lastUsed.Store(time.Now().UnixNano())
+}
+
+func (t *poolTenant) idleFor() time.Duration {
+	return time.Since(time.Unix(0, t.lastUsed.Load()))
+}
+
+// track keeps the tenant in the pool while its client is used, even when it was handed out
+// long ago, so a second client with its own rate limiter is never created for the tenant
+func (t *poolTenant) track(next Doer) Doer {
+	return DoerFunc(func(req *http.Request) (*http.Response, error) {
+		t.inflight.Add(1)
+		t.touch()
+		defer func() {
+			t.touch()
+			t.inflight.Add(-1)
+		}()
+
+		return next.Do(req)
+	})
+}
+
+// NewClientPool creates an empty pool. Close must be called when IdleTimeout is set, to stop
+// the eviction of idle tenants.
+func NewClientPool(opts PoolOptions) *ClientPool {
+	transport := opts.Transport
+	if transport == nil {
+		defaultTransport := http.DefaultTransport.(*http.Transport).Clone()
+		// every tenant talks to the same host
+		defaultTransport.MaxIdleConnsPerHost = 100
+		transport = defaultTransport
+	}
+
+	p := &ClientPool{
+		opts:       opts,
+		httpClient: &http.Client{Transport: transport, Timeout: opts.Timeout},
+		tenants:    map[string]*poolTenant{},
+		stop:       make(chan struct{}),
+	}
+
+	if opts.IdleTimeout > 0 {
+		go p.evictLoop(max(opts.IdleTimeout/2, time.Second))
+	}
+
+	return p
+}
+
+// Client returns the client of the tenant, creating it on first use. When the token differs
+// from the one the tenant was created with, the client starts sending the new token.
+func (p *ClientPool) Client(tenantID, token string) *Client {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	tenant, ok := p.tenants[tenantID]
+	if !ok {
+		tenant = p.newTenant(tenantID, token)
+		p.tenants[tenantID] = tenant
+	}
+
+	tenant.token.set(token)
+	tenant.touch()
+
+	return tenant.client
+}
+
+// RotateToken replaces the token of a tenant for the requests sent from now on, including
+// by the clients already handed out. It returns false when the tenant isn't in the pool.
+func (p *ClientPool) RotateToken(tenantID, token string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	tenant, ok := p.tenants[tenantID]
+	if ok {
+		tenant.token.set(token)
+	}
+
+	return ok
+}
+
+// CircuitState returns the state of the circuit breaker of a tenant, false when the tenant
+// isn't in the pool or the breakers are disabled
+func (p *ClientPool) CircuitState(tenantID string) (CircuitState, bool) {
+	p.mu.Lock()
+	tenant, ok := p.tenants[tenantID]
+	p.mu.Unlock()
+
+	if !ok || tenant.breaker == nil {
+		return CircuitClosed, false
+	}
+
+	return tenant.breaker.State(), true
+}
+
+// Evict removes a tenant from the pool, the next call to Client creates it again
+func (p *ClientPool) Evict(tenantID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.tenants, tenantID)
+}
+
+// EvictIdle removes the tenants unused for longer than IdleTimeout and returns how many were removed
+func (p *ClientPool) EvictIdle() int {
+	if p.opts.IdleTimeout <= 0 {
+		return 0
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	evicted := 0
+	for id, tenant := range p.tenants {
+		if tenant.inflight.Load() == 0 && tenant.idleFor() > p.opts.IdleTimeout {
+			delete(p.tenants, id)
+			evicted++
+		}
+	}
+
+	return evicted
+}
+
+// Len returns the number of tenants in the pool
+func (p *ClientPool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.tenants)
+}
+
+// Close stops the eviction of idle tenants and closes the idle connections
+func (p *ClientPool) Close() {
+	p.closeOnce.Do(func() {
+		close(p.stop)
+		p.httpClient.CloseIdleConnections()
+	})
+}
+
+func (p *ClientPool) newTenant(tenantID, token string) *poolTenant {
+	authenticator := &rotatingTokenAuthenticator{}
+	authenticator.set(token)
+	tenant := &poolTenant{token: authenticator}
+
+	opts := append([]Option{}, p.opts.ClientOptions...)
+	opts = append(opts, WithHttpClient(p.httpClient), WithAuthenticator(authenticator), WithMiddleware(tenant.track), withTenantCache(tenantID))
+
+	if p.opts.RateLimit > 0 {
+		opts = append(opts, WithRateLimiter(NewTokenBucket(p.opts.RateLimit, p.opts.Burst)))
+	}
+
+	if p.opts.CircuitBreaker != nil {
+		settings := *p.opts.CircuitBreaker
+		settings.Name = tenantID
+		tenant.breaker = NewCircuitBreaker(settings)
+		opts = append(opts, WithCircuitBreaker(tenant.breaker))
+	}
+
+	tenant.client = NewClient(opts...)
+
+	return tenant
+}
+
+// withTenantCache moves the cache of the client, if any, to a namespace of the tenant, so a
+// cache shared by the pool never serves the reference data of one account to another
+func withTenantCache(tenantID string) Option {
+	return func(c *Client) {
+		if c.cache != nil {
+			c.cache = c.cache.withNamespace(c.cache.namespace + "tenant:" + tenantID + ":")
+		}
+	}
+}
+
+func (p *ClientPool) evictLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			p.EvictIdle()
+		}
+	}
+}
+
+// rotatingTokenAuthenticator sends the token in the query string, as QueryTokenAuthenticator,
+// and lets the token be replaced while requests are in flight
+type rotatingTokenAuthenticator struct {
+	token atomic.Pointer[string]
+}
+
+func (a *rotatingTokenAuthenticator) set(token string) {
+	a.token.Store(&token)
+}
+
+func (a *rotatingTokenAuthenticator) Authenticate(ctx context.Context, req *http.Request) error {
+	return queryTokenAuthenticator{token: *a.token.Load()}.Authenticate(ctx, req)
+}
@@ -0,0 +1,204 @@
+package rd_station_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rd_station "github.com/verbeux-ai/rd-station-go"
+)
+
+func TestClientPool(t *testing.T) {
+	var mu sync.Mutex
+	var tokens []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		tokens = append(tokens, r.URL.Query().Get("token"))
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{"id":"123"}`))
+	}))
+	defer server.Close()
+
+	pool := rd_station.NewClientPool(rd_station.PoolOptions{
+		ClientOptions: []rd_station.Option{rd_station.WithBaseUrl(server.URL)},
+	})
+	defer pool.Close()
+
+	first := pool.Client("tenant-a", "token-a")
+	assert.Same(t, first, pool.Client("tenant-a", "token-a"))
+	assert.NotSame(t, first, pool.Client("tenant-b", "token-b"))
+	assert.Equal(t, 2, pool.Len())
+
+	_, err := first.GetDeal(context.Background(), "123")
+	require.NoError(t, err)
+
+	assert.True(t, pool.RotateToken("tenant-a", "token-a2"))
+	assert.False(t, pool.RotateToken("unknown", "token"))
+	_, err = first.GetDeal(context.Background(), "123")
+	require.NoError(t, err)
+
+	_, err = pool.Client("tenant-b", "token-b").GetDeal(context.Background(), "123")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"token-a", "token-a2", "token-b"}, tokens)
+
+	pool.Evict("tenant-a")
+	assert.Equal(t, 1, pool.Len())
+	assert.NotSame(t, first, pool.Client("tenant-a", "token-a2"))
+}
+
+func TestClientPoolEvictIdle(t *testing.T) {
+	pool := rd_station.NewClientPool(rd_station.PoolOptions{IdleTimeout: 50 * time.Millisecond})
+	defer pool.Close()
+
+	pool.Client("tenant-a", "token-a")
+	time.Sleep(60 * time.Millisecond)
+	pool.Client("tenant-b", "token-b")
+
+	assert.Equal(t, 1, pool.EvictIdle())
+	assert.Equal(t, 1, pool.Len())
+}
+
+func TestClientPoolCircuitBreaker(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	pool := rd_station.NewClientPool(rd_station.PoolOptions{
+		ClientOptions:  []rd_station.Option{rd_station.WithBaseUrl(server.URL)},
+		CircuitBreaker: &rd_station.CircuitBreakerSettings{FailureThreshold: 2, OpenTimeout: time.Minute},
+	})
+	defer pool.Close()
+
+	client := pool.Client("tenant-a", "token-a")
+	for range 2 {
+		_, err := client.GetDeal(context.Background(), "123")
+		assert.ErrorIs(t, err, rd_station.ErrApiReturnedError)
+	}
+
+	_, err := client.GetDeal(context.Background(), "123")
+	assert.ErrorIs(t, err, rd_station.ErrCircuitOpen)
+	assert.Equal(t, 2, calls)
+
+	state, ok := pool.CircuitState("tenant-a")
+	assert.True(t, ok)
+	assert.Equal(t, rd_station.CircuitOpen, state)
+
+	// the other tenants have their own breaker
+	_, err = pool.Client("tenant-b", "token-b").GetDeal(context.Background(), "123")
+	assert.False(t, errors.Is(err, rd_station.ErrCircuitOpen))
+	assert.Equal(t, 3, calls)
+}
+
+func TestTokenBucket(t *testing.T) {
+	limiter := rd_station.NewTokenBucket(20, 2)
+	ctx := context.Background()
+
+	start := time.Now()
+	for range 4 {
+		require.NoError(t, limiter.Wait(ctx))
+	}
+	// the burst is free, the other two wait 50ms each
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.ErrorIs(t, limiter.Wait(ctx), context.Canceled)
+}
+
+func TestClientPoolKeepsTenantsInUse(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/deals/slow" {
+			<-release
+		}
+		_, _ = w.Write([]byte(`{"id":"123"}`))
+	}))
+	defer server.Close()
+
+	pool := rd_station.NewClientPool(rd_station.PoolOptions{
+		ClientOptions: []rd_station.Option{rd_station.WithBaseUrl(server.URL)},
+		IdleTimeout:   50 * time.Millisecond,
+	})
+	defer pool.Close()
+
+	// the client is held by the caller, the requests keep the tenant alive
+	client := pool.Client("tenant-a", "token-a")
+	time.Sleep(60 * time.Millisecond)
+	_, err := client.GetDeal(context.Background(), "123")
+	require.NoError(t, err)
+	assert.Zero(t, pool.EvictIdle())
+
+	// a request in flight for longer than the timeout keeps it too
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = client.GetDeal(context.Background(), "slow")
+	}()
+	time.Sleep(60 * time.Millisecond)
+	assert.Zero(t, pool.EvictIdle())
+	assert.Same(t, client, pool.Client("tenant-a", "token-a"))
+
+	close(release)
+	<-done
+}
+
+func TestClientPoolSharedCache(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"deal_lost_reasons":[{"id":"1","name":"` + r.URL.Query().Get("token") + `"}]}`))
+	}))
+	defer server.Close()
+
+	cache := rd_station.NewCache(rd_station.CacheOptions{})
+	pool := rd_station.NewClientPool(rd_station.PoolOptions{
+		ClientOptions: []rd_station.Option{rd_station.WithBaseUrl(server.URL), rd_station.WithCache(cache)},
+	})
+	defer pool.Close()
+
+	ctx := context.Background()
+	for range 2 {
+		for _, token := range []string{"token-a", "token-b"} {
+			reasons, err := pool.Client(token, token).ListDealLostReasons(ctx)
+			require.NoError(t, err)
+			require.Len(t, reasons.DealLostReasons, 1)
+			assert.Equal(t, token, reasons.DealLostReasons[0].Name)
+		}
+	}
+}
+
+func TestRateLimiterWaitIsRecorded(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"123"}`))
+	}))
+	defer server.Close()
+
+	var waits []time.Duration
+	client := rd_station.NewClient(
+		rd_station.WithBaseUrl(server.URL),
+		rd_station.WithRateLimiter(rd_station.NewTokenBucket(20, 1)),
+		rd_station.WithMiddleware(func(next rd_station.Doer) rd_station.Doer {
+			return rd_station.DoerFunc(func(req *http.Request) (*http.Response, error) {
+				resp, err := next.Do(req)
+				waits = append(waits, rd_station.RequestStatsFromContext(req.Context()).RateLimitWait)
+				return resp, err
+			})
+		}),
+	)
+
+	for range 2 {
+		_, err := client.GetDeal(context.Background(), "123")
+		require.NoError(t, err)
+	}
+
+	require.Len(t, waits, 2)
+	assert.GreaterOrEqual(t, waits[1], 30*time.Millisecond)
+}
@@ -0,0 +1,283 @@
+package rd_station
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrUnsupportedQueryType = errors.New("unsupported query field type")
+)
+
+// QueryEncoder is implemented by types that encode themselves as query values. Returning
+// no values leaves the parameter out.
+type QueryEncoder interface {
+	EncodeQuery() ([]string, error)
+}
+
+var (
+	queryEncoderType  = reflect.TypeFor[QueryEncoder]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+	timeType          = reflect.TypeFor[time.Time]()
+)
+
+// queryField is how a struct field is encoded, parsed once per type from its tags
+type queryField struct {
+	index     []int
+	name      string
+	omitEmpty bool
+	comma     bool
+	layout    string
+	nested    []queryField
+}
+
+var queryPlans sync.Map // reflect.Type -> []queryField
+
+// EncodeQuery encodes a struct, or a pointer to one, as query values according to the field tags:
+//
+//	query:"name"                   the parameter name, the field name when empty
+//	query:"-"                      skips the field
+//	query:"name,omitempty"         skips zero values, also read from the form tag
+//	query:"name,comma"             joins slices with commas instead of repeating the parameter
+//	query:"name,layout=2006-01-02" formats times with the layout, RFC 3339 by default
+//
+// Nil pointers and empty slices are always skipped. Types implementing QueryEncoder or
+// encoding.TextMarshaler encode themselves, other nested structs are encoded as
+// "name[field]" and embedded structs are flattened.
+func EncodeQuery(data any) (url.Values, error) {
+	v := reflect.ValueOf(data)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return url.Values{}, nil
+		}
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("input is not a struct")
+	}
+
+	// an addressable copy lets pointer receivers implement QueryEncoder
+	addressable := reflect.New(v.Type()).Elem()
+	addressable.Set(v)
+	v = addressable
+
+	values := url.Values{}
+	if err := encodeQueryFields(values, v, queryPlan(v.Type()), ""); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+func queryPlan(t reflect.Type) []queryField {
+	if plan, ok := queryPlans.Load(t); ok {
+		return plan.([]queryField)
+	}
+
+	plan, _ := queryPlans.LoadOrStore(t, buildQueryPlan(t, nil, map[reflect.Type]bool{}))
+	return plan.([]queryField)
+}
+
+// buildQueryPlan lists the encoded fields of t, visiting tracks the nested struct types to
+// stop at recursive ones
+func buildQueryPlan(t reflect.Type, parentIndex []int, visiting map[reflect.Type]bool) []queryField {
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	var plan []queryField
+	for i := range t.NumField() {
+		fieldType := t.Field(i)
+		index := append(append([]int{}, parentIndex...), i)
+
+		tag, hasTag := fieldType.Tag.Lookup("query")
+		if tag == "-" {
+			continue
+		}
+
+		if fieldType.Anonymous && !hasTag {
+			// embedded structs are flattened, through non-nil pointers too
+			embeddedType := fieldType.Type
+			if embeddedType.Kind() == reflect.Pointer {
+				embeddedType = embeddedType.Elem()
+			}
+			if embeddedType.Kind() == reflect.Struct {
+				if !visiting[embeddedType] {
+					plan = append(plan, buildQueryPlan(embeddedType, index, visiting)...)
+				}
+				continue
+			}
+		}
+
+		if !fieldType.IsExported() {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = fieldType.Name
+		}
+
+		field := queryField{index: index, name: name, layout: time.RFC3339}
+		_, formOptions, _ := strings.Cut(fieldType.Tag.Get("form"), ",")
+		for _, option := range strings.Split(options+","+formOptions, ",") {
+			switch {
+			case option == "omitempty":
+				field.omitEmpty = true
+			case option == "comma":
+				field.comma = true
+			case strings.HasPrefix(option, "layout="):
+				field.layout = strings.TrimPrefix(option, "layout=")
+			}
+		}
+
+		elemType := fieldType.Type
+		for elemType.Kind() == reflect.Pointer {
+			elemType = elemType.Elem()
+		}
+		if elemType.Kind() == reflect.Struct && !isQueryScalar(elemType) {
+			if visiting[elemType] {
+				continue
+			}
+			field.nested = buildQueryPlan(elemType, nil, visiting)
+		}
+
+		plan = append(plan, field)
+	}
+
+	return plan
+}
+
+func encodeQueryFields(values url.Values, v reflect.Value, plan []queryField, prefix string) error {
+	for _, field := range plan {
+		fieldValue, ok := fieldByIndex(v, field.index)
+		if !ok {
+			continue
+		}
+
+		name := field.name
+		if prefix != "" {
+			name = prefix + "[" + name + "]"
+		}
+
+		for fieldValue.Kind() == reflect.Pointer && !fieldValue.Type().Implements(queryEncoderType) {
+			if fieldValue.IsNil() {
+				break
+			}
+			fieldValue = fieldValue.Elem()
+		}
+
+		if (fieldValue.Kind() == reflect.Pointer || fieldValue.Kind() == reflect.Interface) && fieldValue.IsNil() {
+			continue
+		}
+
+		if field.omitEmpty && fieldValue.IsZero() {
+			continue
+		}
+
+		if field.nested != nil {
+			if err := encodeQueryFields(values, fieldValue, field.nested, name); err != nil {
+				return err
+			}
+			continue
+		}
+
+		encoded, err := encodeQueryValue(fieldValue, field)
+		if err != nil {
+			return fmt.Errorf("error encoding query field %s: %w", name, err)
+		}
+
+		if field.comma && len(encoded) > 0 {
+			encoded = []string{strings.Join(encoded, ",")}
+		}
+
+		for _, value := range encoded {
+			values.Add(name, value)
+		}
+	}
+
+	return nil
+}
+
+// fieldByIndex is reflect.Value.FieldByIndex without panicking on nil embedded pointers
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, fieldIndex := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(fieldIndex)
+	}
+
+	return v, true
+}
+
+func encodeQueryValue(v reflect.Value, field queryField) ([]string, error) {
+	if v.Type().Implements(queryEncoderType) {
+		return v.Interface().(QueryEncoder).EncodeQuery()
+	}
+	if v.CanAddr() && v.Addr().Type().Implements(queryEncoderType) {
+		return v.Addr().Interface().(QueryEncoder).EncodeQuery()
+	}
+
+	if v.Type() == timeType {
+		return []string{v.Interface().(time.Time).Format(field.layout)}, nil
+	}
+
+	if v.Type().Implements(textMarshalerType) {
+		text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		return []string{string(text)}, nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return []string{v.String()}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return []string{strconv.FormatInt(v.Int(), 10)}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return []string{strconv.FormatUint(v.Uint(), 10)}, nil
+	case reflect.Float32, reflect.Float64:
+		return []string{strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits())}, nil
+	case reflect.Bool:
+		return []string{strconv.FormatBool(v.Bool())}, nil
+	case reflect.Slice, reflect.Array:
+		var encoded []string
+		for i := range v.Len() {
+			elem := v.Index(i)
+			for elem.Kind() == reflect.Pointer && !elem.IsNil() && !elem.Type().Implements(queryEncoderType) {
+				elem = elem.Elem()
+			}
+			if elem.Kind() == reflect.Pointer && elem.IsNil() {
+				continue
+			}
+
+			values, err := encodeQueryValue(elem, field)
+			if err != nil {
+				return nil, err
+			}
+			encoded = append(encoded, values...)
+		}
+		return encoded, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedQueryType, v.Type())
+}
+
+// isQueryScalar reports whether a struct type is encoded as a single value instead of its fields
+func isQueryScalar(t reflect.Type) bool {
+	return t == timeType ||
+		t.Implements(queryEncoderType) || reflect.PointerTo(t).Implements(queryEncoderType) ||
+		t.Implements(textMarshalerType)
+}
@@ -0,0 +1,113 @@
+package rd_station_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rd_station "github.com/verbeux-ai/rd-station-go"
+)
+
+type stageIDs []string
+
+func (s stageIDs) EncodeQuery() ([]string, error) {
+	return []string{strings.Join(s, "|")}, nil
+}
+
+type period struct {
+	Start time.Time `query:"start,layout=2006-01-02"`
+	End   time.Time `query:"end,layout=2006-01-02"`
+}
+
+type embedded struct {
+	Page int `query:"page,omitempty"`
+}
+
+type sorting struct {
+	Order string `query:"order,omitempty"`
+}
+
+type queryFilter struct {
+	embedded
+	*sorting
+	Token    string    `form:"token" query:"-"`
+	Name     string    `form:"name,omitempty" query:"name"`
+	Limit    *int      `query:"limit"`
+	Rating   float64   `query:"rating,omitempty"`
+	Win      *bool     `query:"win"`
+	Users    []string  `query:"user_id"`
+	Products []string  `query:"product_presence,comma"`
+	Stages   stageIDs  `query:"stages,omitempty"`
+	Since    time.Time `query:"since,omitempty"`
+	Period   *period   `query:"period"`
+	Internal string    `query:"-"`
+}
+
+func TestEncodeQuery(t *testing.T) {
+	limit := 0
+	win := false
+	values, err := rd_station.EncodeQuery(queryFilter{
+		embedded: embedded{Page: 2},
+		sorting:  &sorting{Order: "name"},
+		Token:    "secret",
+		Limit:    &limit,
+		Rating:   4.5,
+		Win:      &win,
+		Users:    []string{"ana", "bruno"},
+		Products: []string{"p1", "p2"},
+		Stages:   stageIDs{"lead", "won"},
+		Since:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Period: &period{
+			Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+		},
+		Internal: "skipped",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string][]string{
+		"page":             {"2"},
+		"order":            {"name"},
+		"limit":            {"0"},
+		"rating":           {"4.5"},
+		"win":              {"false"},
+		"user_id":          {"ana", "bruno"},
+		"product_presence": {"p1,p2"},
+		"stages":           {"lead|won"},
+		"since":            {"2024-01-02T03:04:05Z"},
+		"period[start]":    {"2024-01-01"},
+		"period[end]":      {"2024-01-31"},
+	}, map[string][]string(values))
+
+	// nil embedded pointers are skipped
+	values, err = rd_station.EncodeQuery(queryFilter{embedded: embedded{Page: 3}})
+	require.NoError(t, err)
+	assert.Equal(t, "3", values.Get("page"))
+	assert.False(t, values.Has("order"))
+
+	_, err = rd_station.EncodeQuery("not a struct")
+	assert.Error(t, err)
+
+	_, err = rd_station.EncodeQuery(struct {
+		Callback func() `query:"callback"`
+	}{Callback: func() {}})
+	assert.ErrorIs(t, err, rd_station.ErrUnsupportedQueryType)
+}
+
+func TestFilterTokenIsNotSent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, []string{"client-token"}, r.URL.Query()["token"])
+		assert.Equal(t, "2", r.URL.Query().Get("page"))
+		_, _ = w.Write([]byte(`{"deals":[]}`))
+	}))
+	defer server.Close()
+
+	client := rd_station.NewClient(rd_station.WithBaseUrl(server.URL), rd_station.WithToken("client-token"))
+	_, err := client.ListDealsFilter(context.Background(), rd_station.ListDealsFilterRequest{Token: "filter-token", Page: "2"})
+	require.NoError(t, err)
+}
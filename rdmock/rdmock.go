@@ -0,0 +1,71 @@
+// Package rdmock has testify mocks of the rd_station services, to stub the client in unit tests:
+//
+//	client, mocks := rdmock.NewClient(t)
+//	mocks.Deals.On("Get", mock.Anything, "123").Return(&rd_station.GetDealResponse{ID: "123"}, nil)
+//
+// The call options are recorded after the other arguments, one each, so expectations of
+// calls with options match them with mock.Anything:
+//
+//	mocks.Contacts.On("Get", mock.Anything, "123", mock.Anything).Return(nil, rd_station.ErrNotFound)
+//
+// The mocks are generated by mockery from the service interfaces, see .mockery.yaml.
+package rdmock
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.7
+
+import (
+	"github.com/stretchr/testify/mock"
+	rd_station "github.com/verbeux-ai/rd-station-go"
+)
+
+var (
+	_ rd_station.ContactsService      = (*ContactsService)(nil)
+	_ rd_station.DealsService         = (*DealsService)(nil)
+	_ rd_station.PipelinesService     = (*PipelinesService)(nil)
+	_ rd_station.UsersService         = (*UsersService)(nil)
+	_ rd_station.ActivitiesService    = (*ActivitiesService)(nil)
+	_ rd_station.OrganizationsService = (*OrganizationsService)(nil)
+	_ rd_station.CustomFieldsService  = (*CustomFieldsService)(nil)
+)
+
+// TestingT is the part of testing.T used by the mocks
+type TestingT interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// Mocks are the services of a client created by NewClient
+type Mocks struct {
+	Contacts      *ContactsService
+	Deals         *DealsService
+	Pipelines     *PipelinesService
+	Users         *UsersService
+	Activities    *ActivitiesService
+	Organizations *OrganizationsService
+	CustomFields  *CustomFieldsService
+}
+
+// NewClient creates a client whose services are mocks, asserting their expectations when the
+// test ends
+func NewClient(t TestingT, opts ...rd_station.Option) (*rd_station.Client, *Mocks) {
+	mocks := &Mocks{
+		Contacts:      NewContactsService(t),
+		Deals:         NewDealsService(t),
+		Pipelines:     NewPipelinesService(t),
+		Users:         NewUsersService(t),
+		Activities:    NewActivitiesService(t),
+		Organizations: NewOrganizationsService(t),
+		CustomFields:  NewCustomFieldsService(t),
+	}
+
+	client := rd_station.NewClient(opts...)
+	client.Contacts = mocks.Contacts
+	client.Deals = mocks.Deals
+	client.Pipelines = mocks.Pipelines
+	client.Users = mocks.Users
+	client.Activities = mocks.Activities
+	client.Organizations = mocks.Organizations
+	client.CustomFields = mocks.CustomFields
+
+	return client, mocks
+}
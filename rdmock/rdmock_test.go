@@ -0,0 +1,43 @@
+package rdmock_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	rd_station "github.com/verbeux-ai/rd-station-go"
+	"github.com/verbeux-ai/rd-station-go/rdmock"
+)
+
+func TestClientDelegatesToServices(t *testing.T) {
+	client, mocks := rdmock.NewClient(t)
+	ctx := context.Background()
+
+	mocks.Deals.On("Get", mock.Anything, "123").Return(&rd_station.GetDealResponse{ID: "123", UpdatedAt: "v1"}, nil)
+	mocks.Deals.On("Update", mock.Anything, "123", mock.Anything).Return(&rd_station.UpdateDealResponse{ID: "123"}, nil)
+
+	updated, err := client.UpdateDealIfUnchanged(ctx, "123", "v1", rd_station.UpdateDealRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "123", updated.ID)
+
+	// the metadata option is recorded after the id
+	mocks.Contacts.On("Get", mock.Anything, "missing", mock.Anything).Return(nil, rd_station.ErrApiReturnedError)
+
+	contact, err := client.GetContact(ctx, "missing", rd_station.CaptureMetadata(&rd_station.ResponseMetadata{}))
+	assert.Nil(t, contact)
+	assert.ErrorIs(t, err, rd_station.ErrApiReturnedError)
+
+	mocks.Pipelines.On("List", mock.Anything).Return([]rd_station.DealPipeline{{ID: "p1"}}, nil)
+
+	pipelines, err := client.ListDealPipelines(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "p1", pipelines[0].ID)
+
+	mocks.Organizations.On("Get", mock.Anything, "org-1").Return(&rd_station.Organization{ID: "org-1", Name: "Acme"}, nil)
+
+	organization, err := client.GetOrganization(ctx, "org-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Acme", organization.Name)
+}
@@ -0,0 +1,161 @@
+// Package rdotel instruments rd_station.Client with OpenTelemetry spans and metrics.
+//
+//	client := rd_station.NewClient(
+//		rd_station.WithToken(token),
+//		rd_station.WithMiddleware(rdotel.Middleware()),
+//	)
+//
+// Only the endpoint templates are recorded, never the URLs, so identifiers and credentials
+// stay out of the telemetry.
+package rdotel
+
+import (
+	"net/http"
+	"time"
+
+	rd_station "github.com/verbeux-ai/rd-station-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/verbeux-ai/rd-station-go/rdotel"
+
+const (
+	// ErrorCategoryRequestFailed matches rd_station.ErrRequestFailed: the request got no response
+	ErrorCategoryRequestFailed = "request_failed"
+	// ErrorCategoryApiReturnedError matches rd_station.ErrApiReturnedError: the API answered with an error status
+	ErrorCategoryApiReturnedError = "api_returned_error"
+)
+
+var (
+	attrOperation     = attribute.Key("rd_station.operation")
+	attrMethod        = attribute.Key("http.request.method")
+	attrURLTemplate   = attribute.Key("url.template")
+	attrStatusCode    = attribute.Key("http.response.status_code")
+	attrRetryCount    = attribute.Key("rd_station.retry_count")
+	attrPage          = attribute.Key("rd_station.page")
+	attrErrorCategory = attribute.Key("error.type")
+)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+// Option is a function that configures the instrumentation
+type Option func(*config)
+
+// WithTracerProvider sets the tracer provider, the global one by default
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = provider
+	}
+}
+
+// WithMeterProvider sets the meter provider, the global one by default
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = provider
+	}
+}
+
+type instruments struct {
+	tracer        trace.Tracer
+	duration      metric.Float64Histogram
+	errors        metric.Int64Counter
+	rateLimitWait metric.Float64Histogram
+}
+
+// Middleware creates a span for every API operation and records its latency, errors and
+// rate-limit waits. Add it as the outermost middleware so the spans cover the retries.
+func Middleware(opts ...Option) rd_station.Middleware {
+	c := config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+	}
+
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	meter := c.meterProvider.Meter(instrumentationName)
+	i := instruments{tracer: c.tracerProvider.Tracer(instrumentationName)}
+
+	// instrument creation only fails on invalid names, and the noop instruments are kept then
+	i.duration, _ = meter.Float64Histogram("rd_station.client.request.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of RD Station API operations, including retries"))
+	i.errors, _ = meter.Int64Counter("rd_station.client.request.errors",
+		metric.WithDescription("Failed RD Station API operations by error category"))
+	i.rateLimitWait, _ = meter.Float64Histogram("rd_station.client.ratelimit.wait",
+		metric.WithUnit("s"),
+		metric.WithDescription("Time spent waiting for the rate limiter and after rate-limited responses"))
+
+	return func(next rd_station.Doer) rd_station.Doer {
+		return rd_station.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			return i.do(next, req)
+		})
+	}
+}
+
+func (i instruments) do(next rd_station.Doer, req *http.Request) (*http.Response, error) {
+	info, _ := rd_station.RequestInfoFromContext(req.Context())
+	operation := info.Operation
+	if operation == "" {
+		operation = req.Method
+	}
+
+	attrs := []attribute.KeyValue{
+		attrOperation.String(operation),
+		attrMethod.String(req.Method),
+		attrURLTemplate.String(info.EndpointTemplate),
+	}
+
+	ctx, span := i.tracer.Start(req.Context(), "rd_station."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...))
+	defer span.End()
+
+	if info.Page > 0 {
+		span.SetAttributes(attrPage.Int(info.Page))
+	}
+
+	start := time.Now()
+	resp, err := next.Do(req.WithContext(ctx))
+	elapsed := time.Since(start)
+
+	if stats := rd_station.RequestStatsFromContext(ctx); stats != nil {
+		span.SetAttributes(attrRetryCount.Int(stats.Retries))
+		if stats.RateLimited > 0 || stats.RateLimitWait > 0 {
+			i.rateLimitWait.Record(ctx, stats.RateLimitWait.Seconds(), metric.WithAttributes(attrs...))
+		}
+	}
+
+	category := ""
+	switch {
+	case err != nil:
+		category = ErrorCategoryRequestFailed
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	case resp.StatusCode >= http.StatusBadRequest:
+		category = ErrorCategoryApiReturnedError
+		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+	}
+
+	if resp != nil {
+		span.SetAttributes(attrStatusCode.Int(resp.StatusCode))
+		attrs = append(attrs, attrStatusCode.Int(resp.StatusCode))
+	}
+
+	if category != "" {
+		span.SetAttributes(attrErrorCategory.String(category))
+		i.errors.Add(ctx, 1, metric.WithAttributes(append(attrs, attrErrorCategory.String(category))...))
+	}
+
+	i.duration.Record(ctx, elapsed.Seconds(), metric.WithAttributes(attrs...))
+
+	return resp, err
+}
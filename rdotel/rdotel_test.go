@@ -0,0 +1,75 @@
+package rdotel_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rd_station "github.com/verbeux-ai/rd-station-go"
+	"github.com/verbeux-ai/rd-station-go/rdotel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestMiddleware(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+
+	client := rd_station.NewClient(
+		rd_station.WithBaseUrl(server.URL),
+		rd_station.WithToken("super-secret"),
+		rd_station.WithRetryPolicy(rd_station.RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond}),
+		rd_station.WithMiddleware(rdotel.Middleware(
+			rdotel.WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))),
+			rdotel.WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+		)),
+	)
+
+	_, err := client.GetDeal(context.Background(), "123")
+	require.ErrorIs(t, err, rd_station.ErrApiReturnedError)
+
+	ended := spans.Ended()
+	require.Len(t, ended, 1)
+	assert.Equal(t, "rd_station.GetDeal", ended[0].Name())
+
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, attr := range ended[0].Attributes() {
+		attrs[attr.Key] = attr.Value
+		assert.NotContains(t, attr.Value.Emit(), "super-secret")
+	}
+	assert.Equal(t, "api/v1/deals/{id}", attrs["url.template"].AsString())
+	assert.Equal(t, int64(404), attrs["http.response.status_code"].AsInt64())
+	assert.Equal(t, int64(1), attrs["rd_station.retry_count"].AsInt64())
+	assert.Equal(t, rdotel.ErrorCategoryApiReturnedError, attrs["error.type"].AsString())
+
+	var metrics metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &metrics))
+	require.Len(t, metrics.ScopeMetrics, 1)
+
+	var names []string
+	for _, m := range metrics.ScopeMetrics[0].Metrics {
+		names = append(names, m.Name)
+	}
+	assert.ElementsMatch(t, []string{"rd_station.client.request.duration", "rd_station.client.request.errors", "rd_station.client.ratelimit.wait"}, names)
+	assert.False(t, strings.Contains(ended[0].Status().Description, "super-secret"))
+}
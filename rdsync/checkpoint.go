@@ -0,0 +1,128 @@
+package rdsync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Checkpoint is where the sync of an entity type stopped
+type Checkpoint struct {
+	// UpdatedAt is the update time of the last entity delivered to the sink
+	UpdatedAt time.Time `json:"updated_at"`
+	// IDs are the entities delivered with exactly UpdatedAt, skipped by the next pull
+	IDs []string `json:"ids,omitempty"`
+	// ReconciledAt is when the last reconciliation finished
+	ReconciledAt time.Time `json:"reconciled_at"`
+}
+
+// CheckpointStore persists the checkpoints so syncs resume after restarts. Load returns
+// the zero Checkpoint when nothing was stored yet, which makes the next pull a full one.
+type CheckpointStore interface {
+	Load(ctx context.Context, entity EntityType) (Checkpoint, error)
+	Save(ctx context.Context, entity EntityType, checkpoint Checkpoint) error
+}
+
+// MemoryCheckpointStore keeps the checkpoints in memory
+type MemoryCheckpointStore struct {
+	mu          sync.Mutex
+	checkpoints map[EntityType]Checkpoint
+}
+
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{checkpoints: map[EntityType]Checkpoint{}}
+}
+
+func (s *MemoryCheckpointStore) Load(_ context.Context, entity EntityType) (Checkpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.checkpoints[entity], nil
+}
+
+func (s *MemoryCheckpointStore) Save(_ context.Context, entity EntityType, checkpoint Checkpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.checkpoints[entity] = checkpoint
+	return nil
+}
+
+// FileCheckpointStore keeps the checkpoints of every entity type as JSON in a file
+type FileCheckpointStore struct {
+	Path string
+	mu   sync.Mutex
+}
+
+// NewFileCheckpointStore creates a store backed by the file at path
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return &FileCheckpointStore{Path: path}
+}
+
+func (s *FileCheckpointStore) Load(_ context.Context, entity EntityType) (Checkpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	checkpoints, err := s.read()
+	if err != nil {
+		return Checkpoint{}, err
+	}
+
+	return checkpoints[entity], nil
+}
+
+// Save writes to a temporary file first so a crash never leaves a truncated file behind
+func (s *FileCheckpointStore) Save(_ context.Context, entity EntityType, checkpoint Checkpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	checkpoints, err := s.read()
+	if err != nil {
+		return err
+	}
+	checkpoints[entity] = checkpoint
+
+	data, err := json.Marshal(checkpoints)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.Path), filepath.Base(s.Path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.Path)
+}
+
+func (s *FileCheckpointStore) read() (map[EntityType]Checkpoint, error) {
+	checkpoints := map[EntityType]Checkpoint{}
+
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return checkpoints, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &checkpoints); err != nil {
+		return nil, err
+	}
+
+	return checkpoints, nil
+}
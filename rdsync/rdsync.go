@@ -0,0 +1,466 @@
+// Package rdsync mirrors RD Station CRM contacts and deals into a local store.
+//
+// Each pull lists the entities by updated_at, newest first, until it reaches the checkpoint
+// of the previous pull, and delivers the changes to the Sink oldest first, saving the
+// checkpoint after every batch. The API doesn't report deletions, so a periodic
+// reconciliation lists every entity by created_at, oldest first, which is stable while
+// entities change, upserts what the sink is missing or holds an older version of, and
+// deletes from the sink what's gone once the API confirms it with a 404.
+//
+//	syncer := rdsync.New(client, sink, rdsync.NewFileCheckpointStore("checkpoints.json"))
+//	err := syncer.Run(ctx)
+package rdsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	rd_station "github.com/verbeux-ai/rd-station-go"
+)
+
+const (
+	defaultPageSize          = 200
+	defaultInterval          = 5 * time.Minute
+	defaultReconcileInterval = 24 * time.Hour
+)
+
+var (
+	ErrUnknownEntity = errors.New("unknown entity type")
+)
+
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.000-0700",
+	"2006-01-02T15:04:05",
+}
+
+type EntityType string
+
+const (
+	EntityContact EntityType = "contact"
+	EntityDeal    EntityType = "deal"
+)
+
+type EventType string
+
+const (
+	EventUpsert EventType = "upsert"
+	EventDelete EventType = "delete"
+)
+
+// Event is a change to deliver to the sink. Contact or Deal is set on upserts, according to
+// the entity type, and both are nil on deletes.
+type Event struct {
+	Type      EventType
+	Entity    EntityType
+	ID        string
+	UpdatedAt time.Time
+	Contact   *rd_station.Contact
+	Deal      *rd_station.Deal
+}
+
+// Sink receives the changes, e.g. writing them to a database. Apply must be idempotent:
+// after a failure, the events since the last checkpoint are delivered again.
+type Sink interface {
+	Apply(ctx context.Context, events []Event) error
+	// Versions returns the update time of every entity of the type held by the sink, used
+	// by the reconciliation to find deleted and missed entities
+	Versions(ctx context.Context, entity EntityType) (map[string]time.Time, error)
+}
+
+// Source lists the entities, implemented by *rd_station.Client. GetContact and GetDeal
+// confirm deletions, failing with rd_station.ErrNotFound for deleted entities.
+type Source interface {
+	ListContactsFilter(ctx context.Context, filter rd_station.ListContactsFilterRequest, opts ...rd_station.CallOption) (*rd_station.ListContactsFilterResponse, error)
+	ListDealsFilter(ctx context.Context, filter rd_station.ListDealsFilterRequest, opts ...rd_station.CallOption) (*rd_station.ListDealsFilterResponse, error)
+	GetContact(ctx context.Context, contactID string, opts ...rd_station.CallOption) (*rd_station.Contact, error)
+	GetDeal(ctx context.Context, dealID string, opts ...rd_station.CallOption) (*rd_station.GetDealResponse, error)
+}
+
+// listOrder is the sort of a scan
+type listOrder struct {
+	field     string
+	direction string
+}
+
+var (
+	// newestFirst lets a pull stop at the checkpoint
+	newestFirst = listOrder{field: "updated_at", direction: "desc"}
+	// oldestCreatedFirst doesn't move entities between pages when they're updated, and
+	// entities created during the scan go to the end
+	oldestCreatedFirst = listOrder{field: "created_at", direction: "asc"}
+)
+
+type config struct {
+	entities          []EntityType
+	pageSize          int
+	interval          time.Duration
+	reconcileInterval time.Duration
+	onError           func(error)
+	now               func() time.Time
+}
+
+// Option is a function that configures a Syncer
+type Option func(*config)
+
+// WithEntities sets the entity types to sync, contacts and deals by default
+func WithEntities(entities ...EntityType) Option {
+	return func(c *config) {
+		c.entities = entities
+	}
+}
+
+// WithPageSize sets the number of entities requested per page and delivered per batch, 200 by
+// default. Sizes outside 1 to rd_station.MaxPageLimit are ignored, the API would reject them.
+func WithPageSize(size int) Option {
+	return func(c *config) {
+		if size >= 1 && size <= rd_station.MaxPageLimit {
+			c.pageSize = size
+		}
+	}
+}
+
+// WithInterval sets the time between pulls in Run, 5 minutes by default
+func WithInterval(interval time.Duration) Option {
+	return func(c *config) {
+		c.interval = interval
+	}
+}
+
+// WithReconcileInterval sets the minimum time between reconciliations, 24 hours by default
+func WithReconcileInterval(interval time.Duration) Option {
+	return func(c *config) {
+		c.reconcileInterval = interval
+	}
+}
+
+// WithErrorHandler keeps Run going after failed syncs, reporting the errors to handler
+func WithErrorHandler(handler func(error)) Option {
+	return func(c *config) {
+		c.onError = handler
+	}
+}
+
+// WithNow sets the clock used to schedule reconciliations
+func WithNow(now func() time.Time) Option {
+	return func(c *config) {
+		c.now = now
+	}
+}
+
+// Syncer keeps a sink consistent with the CRM
+type Syncer struct {
+	source Source
+	sink   Sink
+	store  CheckpointStore
+	config config
+}
+
+// New creates a syncer delivering the changes listed by source to sink
+func New(source Source, sink Sink, store CheckpointStore, opts ...Option) *Syncer {
+	c := config{
+		entities:          []EntityType{EntityContact, EntityDeal},
+		pageSize:          defaultPageSize,
+		interval:          defaultInterval,
+		reconcileInterval: defaultReconcileInterval,
+		now:               time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return &Syncer{
+		source: source,
+		sink:   sink,
+		store:  store,
+		config: c,
+	}
+}
+
+// Run syncs every interval until the context is done. Without WithErrorHandler, it
+// returns the first error.
+func (s *Syncer) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.config.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.SyncOnce(ctx); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if s.config.onError == nil {
+				return err
+			}
+			s.config.onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// SyncOnce pulls the changes of every entity type and reconciles the ones that are due
+func (s *Syncer) SyncOnce(ctx context.Context) error {
+	for _, entity := range s.config.entities {
+		if _, err := s.Pull(ctx, entity); err != nil {
+			return err
+		}
+
+		checkpoint, err := s.store.Load(ctx, entity)
+		if err != nil {
+			return fmt.Errorf("error loading %s checkpoint: %w", entity, err)
+		}
+
+		if s.config.now().Sub(checkpoint.ReconciledAt) >= s.config.reconcileInterval {
+			if _, err := s.Reconcile(ctx, entity); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Pull delivers the entities updated since the checkpoint and returns how many were delivered
+func (s *Syncer) Pull(ctx context.Context, entity EntityType) (int, error) {
+	checkpoint, err := s.store.Load(ctx, entity)
+	if err != nil {
+		return 0, fmt.Errorf("error loading %s checkpoint: %w", entity, err)
+	}
+
+	var changes []Event
+	seen := map[string]bool{}
+	err = s.scan(ctx, entity, newestFirst, func(event Event) bool {
+		if event.UpdatedAt.IsZero() {
+			// without an update time the entity can't be ordered, it's left to the reconciliation
+			return true
+		}
+
+		if event.UpdatedAt.Before(checkpoint.UpdatedAt) {
+			return false
+		}
+
+		if seen[event.ID] || (event.UpdatedAt.Equal(checkpoint.UpdatedAt) && slices.Contains(checkpoint.IDs, event.ID)) {
+			return true
+		}
+		seen[event.ID] = true
+
+		changes = append(changes, event)
+		return true
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// deliver oldest first, so the checkpoint only moves forward
+	slices.Reverse(changes)
+	slices.SortStableFunc(changes, func(a, b Event) int {
+		return a.UpdatedAt.Compare(b.UpdatedAt)
+	})
+
+	for batch := range slices.Chunk(changes, max(s.config.pageSize, 1)) {
+		if err := s.sink.Apply(ctx, batch); err != nil {
+			return 0, fmt.Errorf("error applying %s changes: %w", entity, err)
+		}
+
+		for _, event := range batch {
+			if !event.UpdatedAt.Equal(checkpoint.UpdatedAt) {
+				checkpoint.UpdatedAt = event.UpdatedAt
+				checkpoint.IDs = nil
+			}
+			checkpoint.IDs = append(checkpoint.IDs, event.ID)
+		}
+
+		if err := s.store.Save(ctx, entity, checkpoint); err != nil {
+			return 0, fmt.Errorf("error saving %s checkpoint: %w", entity, err)
+		}
+	}
+
+	return len(changes), nil
+}
+
+// Reconcile lists every entity of the type, upserts the ones the sink is missing or holds an
+// older version of and deletes from the sink the ones that no longer exist. An entity
+// missing from the listing is only deleted when getting it fails with rd_station.ErrNotFound,
+// since deletions during the scan can still shift others between pages. It returns the
+// number of events delivered.
+func (s *Syncer) Reconcile(ctx context.Context, entity EntityType) (int, error) {
+	versions, err := s.sink.Versions(ctx, entity)
+	if err != nil {
+		return 0, fmt.Errorf("error loading %s versions from sink: %w", entity, err)
+	}
+
+	var events []Event
+	existing := map[string]bool{}
+	err = s.scan(ctx, entity, oldestCreatedFirst, func(event Event) bool {
+		existing[event.ID] = true
+
+		version, ok := versions[event.ID]
+		if !ok || version.Before(event.UpdatedAt) {
+			events = append(events, event)
+		}
+		return true
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for id := range versions {
+		if existing[id] {
+			continue
+		}
+
+		deleted, err := s.deleted(ctx, entity, id)
+		if err != nil {
+			return 0, err
+		}
+		if deleted {
+			events = append(events, Event{Type: EventDelete, Entity: entity, ID: id})
+		}
+	}
+
+	for batch := range slices.Chunk(events, max(s.config.pageSize, 1)) {
+		if err := s.sink.Apply(ctx, batch); err != nil {
+			return 0, fmt.Errorf("error applying %s reconciliation: %w", entity, err)
+		}
+	}
+
+	checkpoint, err := s.store.Load(ctx, entity)
+	if err != nil {
+		return 0, fmt.Errorf("error loading %s checkpoint: %w", entity, err)
+	}
+
+	checkpoint.ReconciledAt = s.config.now()
+	if err := s.store.Save(ctx, entity, checkpoint); err != nil {
+		return 0, fmt.Errorf("error saving %s checkpoint: %w", entity, err)
+	}
+
+	return len(events), nil
+}
+
+// deleted asks the API whether an entity missing from a scan was deleted
+func (s *Syncer) deleted(ctx context.Context, entity EntityType, id string) (bool, error) {
+	var err error
+	switch entity {
+	case EntityContact:
+		_, err = s.source.GetContact(ctx, id)
+	case EntityDeal:
+		_, err = s.source.GetDeal(ctx, id)
+	default:
+		return false, fmt.Errorf("%w: %s", ErrUnknownEntity, entity)
+	}
+
+	switch {
+	case errors.Is(err, rd_station.ErrNotFound):
+		return true, nil
+	case err != nil:
+		return false, fmt.Errorf("error confirming %s %s deletion: %w", entity, id, err)
+	}
+
+	// still there, the scan missed it and the next reconciliation will upsert it if needed
+	return false, nil
+}
+
+// scan lists the entities in the order, calling visit with an upsert event for each one
+// until visit returns false or the list ends
+func (s *Syncer) scan(ctx context.Context, entity EntityType, order listOrder, visit func(Event) bool) error {
+	switch entity {
+	case EntityContact:
+		return s.scanContacts(ctx, order, visit)
+	case EntityDeal:
+		return s.scanDeals(ctx, order, visit)
+	}
+
+	return fmt.Errorf("%w: %s", ErrUnknownEntity, entity)
+}
+
+func (s *Syncer) scanContacts(ctx context.Context, order listOrder, visit func(Event) bool) error {
+	for page := 1; ; page++ {
+		resp, err := s.source.ListContactsFilter(ctx, rd_station.ListContactsFilterRequest{
+			Page:      strconv.Itoa(page),
+			Limit:     strconv.Itoa(s.config.pageSize),
+			Order:     order.field,
+			Direction: order.direction,
+		})
+		if err != nil {
+			return fmt.Errorf("error listing contacts: %w", err)
+		}
+
+		for i := range resp.Contacts {
+			contact := &resp.Contacts[i]
+			if !visit(Event{
+				Type:      EventUpsert,
+				Entity:    EntityContact,
+				ID:        contact.ID,
+				UpdatedAt: parseTime(contact.UpdatedAt),
+				Contact:   contact,
+			}) {
+				return nil
+			}
+		}
+
+		if !resp.HasMore || len(resp.Contacts) == 0 {
+			return nil
+		}
+	}
+}
+
+func (s *Syncer) scanDeals(ctx context.Context, order listOrder, visit func(Event) bool) error {
+	nextPage := ""
+	for page := 1; ; page++ {
+		filter := rd_station.ListDealsFilterRequest{
+			Limit:     strconv.Itoa(s.config.pageSize),
+			Order:     order.field,
+			Direction: order.direction,
+		}
+
+		// the cursor is stable while deals change, prefer it when the API returns one
+		if nextPage != "" {
+			filter.NextPage = nextPage
+		} else {
+			filter.Page = strconv.Itoa(page)
+		}
+
+		resp, err := s.source.ListDealsFilter(ctx, filter)
+		if err != nil {
+			return fmt.Errorf("error listing deals: %w", err)
+		}
+
+		for i := range resp.Deals {
+			deal := &resp.Deals[i]
+			if !visit(Event{
+				Type:      EventUpsert,
+				Entity:    EntityDeal,
+				ID:        deal.ID,
+				UpdatedAt: parseTime(deal.UpdatedAt),
+				Deal:      deal,
+			}) {
+				return nil
+			}
+		}
+
+		if !resp.HasMore || len(resp.Deals) == 0 {
+			return nil
+		}
+		nextPage = resp.NextPage
+	}
+}
+
+// parseTime returns the zero time for unknown formats
+func parseTime(value string) time.Time {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+
+	return time.Time{}
+}
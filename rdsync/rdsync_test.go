@@ -0,0 +1,326 @@
+package rdsync_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rd_station "github.com/verbeux-ai/rd-station-go"
+	"github.com/verbeux-ai/rd-station-go/rdsync"
+)
+
+// fakeSource serves contacts and deals sorted as requested, by updated_at or created_at
+type fakeSource struct {
+	contacts []rd_station.Contact
+	deals    []rd_station.Deal
+	// afterPage is called after serving each page of deals, to change them mid-scan
+	afterPage func(page int)
+	// limit is the page size of the last deals request
+	limit string
+}
+
+func (f *fakeSource) ListContactsFilter(_ context.Context, filter rd_station.ListContactsFilterRequest, _ ...rd_station.CallOption) (*rd_station.ListContactsFilterResponse, error) {
+	contacts := slices.Clone(f.contacts)
+	slices.SortStableFunc(contacts, func(a, b rd_station.Contact) int {
+		return compareBy(filter.Order, filter.Direction, a.CreatedAt, a.UpdatedAt, b.CreatedAt, b.UpdatedAt)
+	})
+
+	page, limit := atoi(filter.Page), atoi(filter.Limit)
+	items, hasMore := pageOf(contacts, page, limit)
+
+	return &rd_station.ListContactsFilterResponse{Contacts: items, HasMore: hasMore}, nil
+}
+
+func (f *fakeSource) ListDealsFilter(_ context.Context, filter rd_station.ListDealsFilterRequest, _ ...rd_station.CallOption) (*rd_station.ListDealsFilterResponse, error) {
+	deals := slices.Clone(f.deals)
+	slices.SortStableFunc(deals, func(a, b rd_station.Deal) int {
+		return compareBy(filter.Order, filter.Direction, a.CreatedAt, a.UpdatedAt, b.CreatedAt, b.UpdatedAt)
+	})
+
+	f.limit = filter.Limit
+	page, limit := atoi(filter.Page), atoi(filter.Limit)
+	items, hasMore := pageOf(deals, page, limit)
+	if f.afterPage != nil {
+		f.afterPage(page)
+	}
+
+	return &rd_station.ListDealsFilterResponse{Deals: items, HasMore: hasMore}, nil
+}
+
+func (f *fakeSource) GetContact(_ context.Context, contactID string, _ ...rd_station.CallOption) (*rd_station.Contact, error) {
+	for _, contact := range f.contacts {
+		if contact.ID == contactID {
+			return &contact, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %w", rd_station.ErrApiReturnedError, rd_station.ErrNotFound)
+}
+
+func (f *fakeSource) GetDeal(_ context.Context, dealID string, _ ...rd_station.CallOption) (*rd_station.GetDealResponse, error) {
+	for _, deal := range f.deals {
+		if deal.ID == dealID {
+			return &rd_station.GetDealResponse{ID: deal.ID}, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %w", rd_station.ErrApiReturnedError, rd_station.ErrNotFound)
+}
+
+var _ rdsync.Source = (*rd_station.Client)(nil)
+
+func pageOf[T any](items []T, page, limit int) ([]T, bool) {
+	start := min((page-1)*limit, len(items))
+	end := min(start+limit, len(items))
+	return items[start:end], end < len(items)
+}
+
+func compareBy(order, direction, aCreatedAt, aUpdatedAt, bCreatedAt, bUpdatedAt string) int {
+	cmp := compareStrings(aUpdatedAt, bUpdatedAt)
+	if order == "created_at" {
+		cmp = compareStrings(aCreatedAt, bCreatedAt)
+	}
+	if direction == "desc" {
+		return -cmp
+	}
+	return cmp
+}
+
+func compareStrings(a, b string) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func atoi(value string) int {
+	n, _ := strconv.Atoi(value)
+	return n
+}
+
+type memorySink struct {
+	mu       sync.Mutex
+	versions map[rdsync.EntityType]map[string]time.Time
+	applied  []string
+	fail     bool
+}
+
+func newMemorySink() *memorySink {
+	return &memorySink{versions: map[rdsync.EntityType]map[string]time.Time{}}
+}
+
+func (s *memorySink) Apply(_ context.Context, events []rdsync.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.fail {
+		return errors.New("database is down")
+	}
+
+	for _, event := range events {
+		versions, ok := s.versions[event.Entity]
+		if !ok {
+			versions = map[string]time.Time{}
+			s.versions[event.Entity] = versions
+		}
+
+		s.applied = append(s.applied, string(event.Type)+":"+event.ID)
+		if event.Type == rdsync.EventDelete {
+			delete(versions, event.ID)
+			continue
+		}
+		versions[event.ID] = event.UpdatedAt
+	}
+
+	return nil
+}
+
+func (s *memorySink) Versions(_ context.Context, entity rdsync.EntityType) (map[string]time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return maps.Clone(s.versions[entity]), nil
+}
+
+func (s *memorySink) takeApplied() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	applied := s.applied
+	s.applied = nil
+	return applied
+}
+
+func contact(id, updatedAt string) rd_station.Contact {
+	return rd_station.Contact{ID: id, UpdatedAt: updatedAt}
+}
+
+func TestPull(t *testing.T) {
+	ctx := context.Background()
+	source := &fakeSource{contacts: []rd_station.Contact{
+		contact("a", "2024-01-01T10:00:00.000-03:00"),
+		contact("b", "2024-01-02T10:00:00.000-03:00"),
+		contact("c", "2024-01-02T10:00:00.000-03:00"),
+		contact("d", "2024-01-03T10:00:00.000-03:00"),
+	}}
+	sink := newMemorySink()
+	store := rdsync.NewMemoryCheckpointStore()
+	syncer := rdsync.New(source, sink, store, rdsync.WithEntities(rdsync.EntityContact), rdsync.WithPageSize(2))
+
+	delivered, err := syncer.Pull(ctx, rdsync.EntityContact)
+	require.NoError(t, err)
+	assert.Equal(t, 4, delivered)
+	assert.Equal(t, []string{"upsert:a", "upsert:c", "upsert:b", "upsert:d"}, sink.takeApplied())
+
+	// nothing changed: the entities at the checkpoint time aren't delivered again
+	delivered, err = syncer.Pull(ctx, rdsync.EntityContact)
+	require.NoError(t, err)
+	assert.Zero(t, delivered)
+
+	source.contacts[1].UpdatedAt = "2024-01-04T10:00:00.000-03:00"
+	source.contacts = append(source.contacts, contact("e", "2024-01-03T10:00:00.000-03:00"))
+	delivered, err = syncer.Pull(ctx, rdsync.EntityContact)
+	require.NoError(t, err)
+	assert.Equal(t, 2, delivered)
+	assert.Equal(t, []string{"upsert:e", "upsert:b"}, sink.takeApplied())
+
+	checkpoint, err := store.Load(ctx, rdsync.EntityContact)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b"}, checkpoint.IDs)
+}
+
+func TestPullKeepsCheckpointWhenSinkFails(t *testing.T) {
+	ctx := context.Background()
+	source := &fakeSource{deals: []rd_station.Deal{
+		{ID: "a", UpdatedAt: "2024-01-01T10:00:00Z"},
+	}}
+	sink := newMemorySink()
+	sink.fail = true
+	store := rdsync.NewMemoryCheckpointStore()
+	syncer := rdsync.New(source, sink, store)
+
+	_, err := syncer.Pull(ctx, rdsync.EntityDeal)
+	require.Error(t, err)
+
+	checkpoint, err := store.Load(ctx, rdsync.EntityDeal)
+	require.NoError(t, err)
+	assert.Zero(t, checkpoint)
+
+	sink.fail = false
+	delivered, err := syncer.Pull(ctx, rdsync.EntityDeal)
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+}
+
+func TestPageSizeAboveLimitIsIgnored(t *testing.T) {
+	source := &fakeSource{}
+	syncer := rdsync.New(source, newMemorySink(), rdsync.NewMemoryCheckpointStore(), rdsync.WithPageSize(500))
+
+	_, err := syncer.Pull(context.Background(), rdsync.EntityDeal)
+	require.NoError(t, err)
+	assert.Equal(t, "200", source.limit)
+}
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+	source := &fakeSource{deals: []rd_station.Deal{
+		{ID: "a", UpdatedAt: "2024-01-01T10:00:00Z"},
+		{ID: "b", UpdatedAt: "2024-01-02T10:00:00Z"},
+		{ID: "c", UpdatedAt: "2024-01-03T10:00:00Z"},
+	}}
+	sink := newMemorySink()
+	now := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	syncer := rdsync.New(source, sink, rdsync.NewMemoryCheckpointStore(),
+		rdsync.WithEntities(rdsync.EntityDeal),
+		rdsync.WithNow(func() time.Time { return now }),
+	)
+
+	// the first sync pulls everything and reconciles right away
+	require.NoError(t, syncer.SyncOnce(ctx))
+	assert.Equal(t, []string{"upsert:a", "upsert:b", "upsert:c"}, sink.takeApplied())
+
+	// "b" is deleted and "a" changes in place without a newer update time being pulled
+	source.deals = []rd_station.Deal{
+		{ID: "a", UpdatedAt: "2024-01-01T10:00:00Z"},
+		{ID: "c", UpdatedAt: "2024-01-03T10:00:00Z"},
+	}
+	sink.versions[rdsync.EntityDeal]["a"] = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// reconciliation isn't due yet
+	require.NoError(t, syncer.SyncOnce(ctx))
+	assert.Empty(t, sink.takeApplied())
+
+	now = now.Add(25 * time.Hour)
+	require.NoError(t, syncer.SyncOnce(ctx))
+	assert.Equal(t, []string{"upsert:a", "delete:b"}, sink.takeApplied())
+}
+
+func TestReconcileWhileDealsChange(t *testing.T) {
+	ctx := context.Background()
+	deal := func(id, createdAt, updatedAt string) rd_station.Deal {
+		return rd_station.Deal{ID: id, CreatedAt: createdAt, UpdatedAt: updatedAt}
+	}
+	source := &fakeSource{deals: []rd_station.Deal{
+		deal("a", "2024-01-01T10:00:00Z", "2024-01-01T10:00:00Z"),
+		deal("b", "2024-01-02T10:00:00Z", "2024-01-02T10:00:00Z"),
+		deal("c", "2024-01-03T10:00:00Z", "2024-01-03T10:00:00Z"),
+		deal("d", "2024-01-04T10:00:00Z", "2024-01-04T10:00:00Z"),
+		deal("e", "2024-01-05T10:00:00Z", "2024-01-05T10:00:00Z"),
+	}}
+	sink := newMemorySink()
+	syncer := rdsync.New(source, sink, rdsync.NewMemoryCheckpointStore(),
+		rdsync.WithEntities(rdsync.EntityDeal),
+		rdsync.WithPageSize(2),
+	)
+
+	_, err := syncer.Reconcile(ctx, rdsync.EntityDeal)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"upsert:a", "upsert:b", "upsert:c", "upsert:d", "upsert:e"}, sink.takeApplied())
+
+	// "e" is deleted before the scan. After the first page "a" is deleted too, shifting "c"
+	// to the page already served, and "d" is updated, which would move it to the first page
+	// if the scan were by updated_at.
+	source.deals = source.deals[:4]
+	source.afterPage = func(page int) {
+		if page != 1 {
+			return
+		}
+		source.deals = []rd_station.Deal{
+			deal("b", "2024-01-02T10:00:00Z", "2024-01-02T10:00:00Z"),
+			deal("c", "2024-01-03T10:00:00Z", "2024-01-03T10:00:00Z"),
+			deal("d", "2024-01-04T10:00:00Z", "2024-01-05T10:00:00Z"),
+		}
+	}
+
+	_, err = syncer.Reconcile(ctx, rdsync.EntityDeal)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"upsert:d", "delete:e"}, sink.takeApplied(), "the skipped deal is still there and isn't deleted")
+}
+
+func TestFileCheckpointStore(t *testing.T) {
+	ctx := context.Background()
+	store := rdsync.NewFileCheckpointStore(filepath.Join(t.TempDir(), "checkpoints.json"))
+
+	checkpoint, err := store.Load(ctx, rdsync.EntityDeal)
+	require.NoError(t, err)
+	assert.Zero(t, checkpoint)
+
+	saved := rdsync.Checkpoint{UpdatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), IDs: []string{"a"}}
+	require.NoError(t, store.Save(ctx, rdsync.EntityDeal, saved))
+	require.NoError(t, store.Save(ctx, rdsync.EntityContact, rdsync.Checkpoint{IDs: []string{"b"}}))
+
+	checkpoint, err = store.Load(ctx, rdsync.EntityDeal)
+	require.NoError(t, err)
+	assert.True(t, saved.UpdatedAt.Equal(checkpoint.UpdatedAt))
+	assert.Equal(t, saved.IDs, checkpoint.IDs)
+}
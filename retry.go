@@ -0,0 +1,135 @@
+package rd_station
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy retries failed requests with exponential backoff and jitter. Requests with
+// non-idempotent methods (POST, PATCH) are only retried when the API answers 429, since
+// that guarantees nothing was written. The zero value never retries.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one
+	MaxAttempts int
+	MinBackoff  time.Duration
+	MaxBackoff  time.Duration
+}
+
+// DefaultRetryPolicy returns a policy with 3 attempts and backoff between 500ms and 10s
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  500 * time.Millisecond,
+		MaxBackoff:  10 * time.Second,
+	}
+}
+
+// Do calls send until it succeeds, the error is not retryable or the attempts are exhausted.
+// send must build a new request on every call so the body can be read again.
+func (p RetryPolicy) Do(ctx context.Context, method string, send func() (*http.Response, error)) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := send()
+		if attempt >= p.MaxAttempts || !p.shouldRetry(ctx, method, resp, err) {
+			return resp, err
+		}
+
+		wait := p.backoff(ctx, attempt, resp)
+		if stats := RequestStatsFromContext(ctx); stats != nil {
+			stats.Retries++
+			if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+				stats.RateLimited++
+				stats.RateLimitWait += wait
+			}
+		}
+
+		if resp != nil {
+			drainAndClose(resp)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (p RetryPolicy) shouldRetry(ctx context.Context, method string, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	if method == http.MethodPost || method == http.MethodPatch {
+		return false
+	}
+
+	if err != nil {
+		// credentials that can't be obtained, e.g. a revoked refresh token, won't recover
+		return !errors.Is(err, ErrAuthentication)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// backoff returns the wait before the next attempt. A Retry-After from the server is honoured
+// up to MaxBackoff and the context deadline, so a bad header can't stall the caller.
+func (p RetryPolicy) backoff(ctx context.Context, attempt int, resp *http.Response) time.Duration {
+	if wait, ok := retryAfter(resp); ok {
+		if p.MaxBackoff > 0 {
+			wait = min(wait, p.MaxBackoff)
+		}
+		if deadline, ok := ctx.Deadline(); ok {
+			wait = min(wait, max(time.Until(deadline), 0))
+		}
+		return wait
+	}
+
+	wait := p.MinBackoff << (attempt - 1)
+	if p.MaxBackoff > 0 && (wait > p.MaxBackoff || wait <= 0) {
+		wait = p.MaxBackoff
+	}
+
+	if wait <= 0 {
+		return 0
+	}
+
+	// full jitter, so concurrent clients don't retry in lockstep
+	return time.Duration(rand.Int64N(int64(wait)) + 1)
+}
+
+// retryAfter reads the Retry-After header, either in seconds or as an HTTP date
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,77 @@
+package rd_station_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rd_station "github.com/verbeux-ai/rd-station-go"
+)
+
+func TestRetryPolicyRetriesServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"deals":[],"has_more":false,"total":0}`))
+	}))
+	defer server.Close()
+
+	client := rd_station.NewClient(
+		rd_station.WithBaseUrl(server.URL),
+		rd_station.WithRetryPolicy(rd_station.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}),
+	)
+
+	_, err := client.ListDealsFilter(context.Background(), rd_station.ListDealsFilterRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestRetryPolicyDoesNotRetryPostOnServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := rd_station.NewClient(
+		rd_station.WithBaseUrl(server.URL),
+		rd_station.WithRetryPolicy(rd_station.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond}),
+	)
+
+	_, err := client.CreateDeal(context.Background(), rd_station.CreateDealRequest{Deal: rd_station.CreateDealData{Name: "Deal"}})
+	require.ErrorIs(t, err, rd_station.ErrApiReturnedError)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestRetryPolicyCapsRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"deals":[],"has_more":false,"total":0}`))
+	}))
+	defer server.Close()
+
+	client := rd_station.NewClient(
+		rd_station.WithBaseUrl(server.URL),
+		rd_station.WithRetryPolicy(rd_station.RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond}),
+	)
+
+	start := time.Now()
+	_, err := client.ListDealsFilter(context.Background(), rd_station.ListDealsFilterRequest{})
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, int32(2), calls.Load())
+}
@@ -0,0 +1,100 @@
+package rd_station
+
+import "context"
+
+// ContactsService sends the requests of the contacts API
+type ContactsService interface {
+	List(ctx context.Context, filter ListContactsFilterRequest, opts ...CallOption) (*ListContactsFilterResponse, error)
+	Get(ctx context.Context, contactID string, opts ...CallOption) (*Contact, error)
+	Create(ctx context.Context, contact CreateContactRequest, opts ...CallOption) (*CreateContactResponse, error)
+	Update(ctx context.Context, contactID string, contact UpdateContactRequest, opts ...CallOption) (*UpdateContactResponse, error)
+	// FindByWhatsApp looks up contacts by a WhatsApp number, see Client.FindContactsByWhatsApp
+	FindByWhatsApp(ctx context.Context, number string, opts ...CallOption) ([]Contact, error)
+}
+
+// DealsService sends the requests of the deals API
+type DealsService interface {
+	List(ctx context.Context, filter ListDealsFilterRequest, opts ...CallOption) (*ListDealsFilterResponse, error)
+	Get(ctx context.Context, dealID string, opts ...CallOption) (*GetDealResponse, error)
+	Create(ctx context.Context, deal CreateDealRequest, opts ...CallOption) (*CreateDealResponse, error)
+	Update(ctx context.Context, dealID string, deal UpdateDealRequest, opts ...CallOption) (*UpdateDealResponse, error)
+	// ListContacts lists the contacts associated with the deal
+	ListContacts(ctx context.Context, dealID string, filter ListDealContactsFilterRequest, opts ...CallOption) (*ListDealContactsResponse, error)
+	// MoveToStage moves the deal to another stage of the same pipeline
+	MoveToStage(ctx context.Context, dealID, stageID string, opts ...CallOption) (*UpdateDealResponse, error)
+	// MarkWon closes the deal as won
+	MarkWon(ctx context.Context, dealID string, opts ...CallOption) (*UpdateDealResponse, error)
+	// MarkLost closes the deal as lost with one of the account lost reasons and an optional note
+	MarkLost(ctx context.Context, dealID, reasonID, note string, opts ...CallOption) (*UpdateDealResponse, error)
+	// Pause puts the deal on hold
+	Pause(ctx context.Context, dealID string, opts ...CallOption) (*UpdateDealResponse, error)
+	// Resume removes the deal from hold
+	Resume(ctx context.Context, dealID string, opts ...CallOption) (*UpdateDealResponse, error)
+}
+
+// PipelinesService sends the requests of the deal pipelines, stages and lost reasons APIs
+type PipelinesService interface {
+	List(ctx context.Context, opts ...CallOption) ([]DealPipeline, error)
+	ListStages(ctx context.Context, filter ListDealStagesFilterRequest, opts ...CallOption) (*ListDealStagesFilterResponse, error)
+	ListLostReasons(ctx context.Context, opts ...CallOption) (*ListDealLostReasonsResponse, error)
+	CreateLostReason(ctx context.Context, reason DealLostReasonRequest, opts ...CallOption) (*DealLostReason, error)
+	UpdateLostReason(ctx context.Context, reasonID string, reason DealLostReasonRequest, opts ...CallOption) (*DealLostReason, error)
+}
+
+// UsersService sends the requests of the users API
+type UsersService interface {
+	List(ctx context.Context, opts ...CallOption) (*ListUsersResponse, error)
+}
+
+// ActivitiesService sends the requests of the activities API
+type ActivitiesService interface {
+	List(ctx context.Context, filter ListActivitiesFilterRequest, opts ...CallOption) (*ListActivitiesResponse, error)
+}
+
+// OrganizationsService sends the requests of the organizations API
+type OrganizationsService interface {
+	Get(ctx context.Context, organizationID string, opts ...CallOption) (*Organization, error)
+}
+
+// CustomFieldsService sends the requests of the custom fields API
+type CustomFieldsService interface {
+	List(ctx context.Context, filter ListCustomFieldsFilterRequest, opts ...CallOption) (*ListCustomFieldsResponse, error)
+}
+
+type contactsService struct {
+	client *Client
+}
+
+type dealsService struct {
+	client *Client
+}
+
+type pipelinesService struct {
+	client *Client
+}
+
+type usersService struct {
+	client *Client
+}
+
+type activitiesService struct {
+	client *Client
+}
+
+type organizationsService struct {
+	client *Client
+}
+
+type customFieldsService struct {
+	client *Client
+}
+
+func (c *Client) initServices() {
+	c.Contacts = &contactsService{client: c}
+	c.Deals = &dealsService{client: c}
+	c.Pipelines = &pipelinesService{client: c}
+	c.Users = &usersService{client: c}
+	c.Activities = &activitiesService{client: c}
+	c.Organizations = &organizationsService{client: c}
+	c.CustomFields = &customFieldsService{client: c}
+}
@@ -0,0 +1,26 @@
+package rd_station
+
+import "context"
+
+// AccountUser is a user of the CRM account, with the fields returned when listing users
+type AccountUser struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Nickname  string `json:"nickname"`
+	Email     string `json:"email"`
+	Active    bool   `json:"active"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type ListUsersResponse struct {
+	Users []AccountUser `json:"users"`
+}
+
+func (s *usersService) List(ctx context.Context, opts ...CallOption) (*ListUsersResponse, error) {
+	return do[ListUsersResponse](withCallOptions(ctx, opts), s.client, listUsersEndpoint, nil)
+}
+
+func (s *Client) ListUsers(ctx context.Context, opts ...CallOption) (*ListUsersResponse, error) {
+	return s.Users.List(ctx, opts...)
+}
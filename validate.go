@@ -0,0 +1,364 @@
+package rd_station
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrValidation = errors.New("invalid request")
+)
+
+// MaxPageLimit is the largest page the API returns, larger limits fail validation
+const MaxPageLimit = 200
+
+const (
+	minDealRating = 1
+	maxDealRating = 5
+)
+
+// predictionDateLayouts are the ISO 8601 formats accepted for dates
+var predictionDateLayouts = []string{time.DateOnly, "2006-01-02T15:04:05", time.RFC3339}
+
+// Validator is implemented by the requests checked before sending, see WithoutValidation
+type Validator interface {
+	Validate() error
+}
+
+// FieldError is an invalid field, named by its JSON path, e.g.: "deal.rating"
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// ValidationError lists every invalid field of a request, it matches ErrValidation
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		messages[i] = field.Error()
+	}
+
+	return fmt.Sprintf("%s: %s", ErrValidation, strings.Join(messages, "; "))
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrValidation
+}
+
+// WithoutValidation sends the requests without validating them first
+func WithoutValidation() Option {
+	return func(c *Client) {
+		c.skipValidation = true
+	}
+}
+
+// SkipValidation sends the request of the call without validating it first
+func SkipValidation() CallOption {
+	return func(c *callConfig) {
+		c.skipValidation = true
+	}
+}
+
+func (r CreateContactRequest) Validate() error {
+	var v validator
+	data := r.Contact
+
+	v.required("contact.name", data.Name)
+	if data.Birthday != nil {
+		v.birthday("contact.birthday", *data.Birthday)
+	}
+	if data.Emails != nil {
+		v.emails("contact.emails", *data.Emails)
+	}
+	if data.Phones != nil {
+		for i, phone := range *data.Phones {
+			v.required(fmt.Sprintf("contact.phones[%d].phone", i), phone.Phone)
+		}
+	}
+	if data.ContactCustomFields != nil {
+		v.contactCustomFields("contact.contact_custom_fields", *data.ContactCustomFields)
+	}
+	if data.LegalBases != nil {
+		v.legalBases("contact.legal_bases", *data.LegalBases)
+	}
+
+	return v.err()
+}
+
+func (r UpdateContactRequest) Validate() error {
+	var v validator
+	data := r.Contact
+
+	v.notCleared("contact.name", data.Name)
+	if birthday, ok := data.Birthday.Get(); ok {
+		v.birthday("contact.birthday", birthday)
+	}
+	if emails, ok := data.Emails.Get(); ok {
+		v.emails("contact.emails", emails)
+	}
+	if phones, ok := data.Phones.Get(); ok {
+		for i, phone := range phones {
+			v.required(fmt.Sprintf("contact.phones[%d].phone", i), phone.Phone)
+		}
+	}
+	if fields, ok := data.ContactCustomFields.Get(); ok {
+		v.contactCustomFields("contact.contact_custom_fields", fields)
+	}
+	if bases, ok := data.LegalBases.Get(); ok {
+		v.legalBases("contact.legal_bases", bases)
+	}
+
+	return v.err()
+}
+
+func (r CreateDealRequest) Validate() error {
+	var v validator
+	data := r.Deal
+
+	v.required("deal.name", data.Name)
+	if data.Rating != nil {
+		v.rating("deal.rating", float64(*data.Rating))
+	}
+	if data.PredictionDate != nil {
+		v.date("deal.prediction_date", *data.PredictionDate)
+	}
+	for i, contact := range r.SetContacts {
+		v.required(fmt.Sprintf("set_contacts[%d].id", i), contact.ID)
+	}
+
+	return v.err()
+}
+
+func (r UpdateDealRequest) Validate() error {
+	var v validator
+	data := r.Deal
+
+	v.notCleared("deal.name", data.Name)
+	if rating, ok := data.Rating.Get(); ok {
+		v.rating("deal.rating", rating)
+	}
+	if date, ok := data.PredictionDate.Get(); ok {
+		v.date("deal.prediction_date", date)
+	}
+	if win, ok := data.Win.Get(); ok {
+		v.oneOf("deal.win", win, "true", "false", "null")
+	}
+	if hold, ok := data.Hold.Get(); ok {
+		v.oneOf("deal.hold", hold, "true", "false")
+	}
+	if fields, ok := data.DealCustomFields.Get(); ok {
+		for i, field := range fields {
+			v.required(fmt.Sprintf("deal.deal_custom_fields[%d].custom_field_id", i), field.CustomFieldID)
+		}
+	}
+
+	return v.err()
+}
+
+func (r DealLostReasonRequest) Validate() error {
+	var v validator
+	v.required("deal_lost_reason.name", r.DealLostReason.Name)
+
+	return v.err()
+}
+
+func (r ListContactsFilterRequest) Validate() error {
+	var v validator
+	v.page(r.Page, r.Limit)
+	v.direction(r.Direction)
+	if r.Email != "" {
+		v.email("email", r.Email)
+	}
+
+	return v.err()
+}
+
+func (r ListDealsFilterRequest) Validate() error {
+	var v validator
+	v.page(r.Page, r.Limit)
+	v.direction(r.Direction)
+	v.optionalOneOf("win", r.Win, "true", "false", "null")
+	v.optionalOneOf("exact_name", r.ExactName, "true", "false")
+	v.optionalOneOf("closed_at", r.ClosedAt, "true", "false")
+	v.optionalOneOf("hold", r.Hold, "true", "false")
+
+	periods := []struct{ name, flag string }{
+		{"closed_at_period", r.ClosedAtPeriod},
+		{"created_at_period", r.CreatedAtPeriod},
+		{"prediction_date_period", r.PredictionDatePeriod},
+	}
+	for _, period := range periods {
+		v.optionalOneOf(period.name, period.flag, "true", "false")
+		if period.flag == "true" && (r.StartDate == "" || r.EndDate == "") {
+			v.add(period.name, "requires start_date and end_date")
+		}
+	}
+
+	if r.StartDate != "" {
+		v.date("start_date", r.StartDate)
+	}
+	if r.EndDate != "" {
+		v.date("end_date", r.EndDate)
+	}
+
+	return v.err()
+}
+
+func (r ListDealStagesFilterRequest) Validate() error {
+	var v validator
+	v.page(r.Page, r.Limit)
+
+	return v.err()
+}
+
+func (r ListCustomFieldsFilterRequest) Validate() error {
+	var v validator
+	v.optionalOneOf("option", string(r.For), string(CustomFieldForDeal), string(CustomFieldForContact), string(CustomFieldForOrganization))
+
+	return v.err()
+}
+
+// validator collects the field errors of a request
+type validator struct {
+	fields []FieldError
+}
+
+func (v *validator) add(field, format string, args ...any) {
+	v.fields = append(v.fields, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+
+	return &ValidationError{Fields: v.fields}
+}
+
+func (v *validator) required(field, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.add(field, "is required")
+	}
+}
+
+// notCleared rejects setting a required field to null or blank
+func (v *validator) notCleared(field string, value Optional[string]) {
+	if !value.IsSet() {
+		return
+	}
+
+	if text, ok := value.Get(); !ok || strings.TrimSpace(text) == "" {
+		v.add(field, "can't be empty")
+	}
+}
+
+func (v *validator) oneOf(field, value string, allowed ...string) {
+	for _, option := range allowed {
+		if value == option {
+			return
+		}
+	}
+
+	v.add(field, "must be one of %s, got %q", strings.Join(allowed, ", "), value)
+}
+
+func (v *validator) optionalOneOf(field, value string, allowed ...string) {
+	if value != "" {
+		v.oneOf(field, value, allowed...)
+	}
+}
+
+func (v *validator) email(field, value string) {
+	address, err := mail.ParseAddress(value)
+	if err != nil || address.Address != value {
+		v.add(field, "invalid email %q", value)
+	}
+}
+
+func (v *validator) emails(field string, emails []EmailData) {
+	for i, email := range emails {
+		v.email(fmt.Sprintf("%s[%d].email", field, i), email.Email)
+	}
+}
+
+func (v *validator) contactCustomFields(field string, fields []ContactCustomField) {
+	for i, customField := range fields {
+		v.required(fmt.Sprintf("%s[%d].custom_field_id", field, i), customField.CustomFieldID)
+	}
+}
+
+func (v *validator) legalBases(field string, bases []LegalBasis) {
+	for i, basis := range bases {
+		v.legalBasis(fmt.Sprintf("%s[%d]", field, i), basis)
+	}
+}
+
+func (v *validator) legalBasis(field string, basis LegalBasis) {
+	if !basis.Category.IsValid() {
+		v.add(field+".category", "unknown category %q", basis.Category)
+	}
+	if !basis.Type.IsValid() {
+		v.add(field+".type", "unknown type %q", basis.Type)
+	}
+	if !basis.Status.IsValid() {
+		v.add(field+".status", "unknown status %q", basis.Status)
+	}
+}
+
+func (v *validator) birthday(field string, birthday BirthdayData) {
+	if birthday.Day < 1 || birthday.Day > 31 {
+		v.add(field+".day", "must be between 1 and 31")
+	}
+	if birthday.Month < 1 || birthday.Month > 12 {
+		v.add(field+".month", "must be between 1 and 12")
+	}
+	if birthday.Year < 0 {
+		v.add(field+".year", "can't be negative")
+	}
+}
+
+func (v *validator) rating(field string, rating float64) {
+	if rating < minDealRating || rating > maxDealRating {
+		v.add(field, "must be between %d and %d", minDealRating, maxDealRating)
+	}
+}
+
+func (v *validator) date(field, value string) {
+	for _, layout := range predictionDateLayouts {
+		if _, err := time.Parse(layout, value); err == nil {
+			return
+		}
+	}
+
+	v.add(field, "must be an ISO 8601 date, e.g.: 2020-12-14 or 2020-12-14T15:00:00, got %q", value)
+}
+
+func (v *validator) page(page, limit string) {
+	if page != "" {
+		if value, err := strconv.Atoi(page); err != nil || value < 1 {
+			v.add("page", "must be a positive number")
+		}
+	}
+
+	if limit != "" {
+		if value, err := strconv.Atoi(limit); err != nil || value < 1 || value > MaxPageLimit {
+			v.add("limit", "must be a number between 1 and %d", MaxPageLimit)
+		}
+	}
+}
+
+func (v *validator) direction(direction string) {
+	v.optionalOneOf("direction", direction, "asc", "desc")
+}
@@ -0,0 +1,118 @@
+package rd_station_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rd_station "github.com/verbeux-ai/rd-station-go"
+)
+
+func TestValidateRequests(t *testing.T) {
+	rating := 7
+	date := "14/12/2020"
+	err := rd_station.CreateDealRequest{
+		Deal: rd_station.CreateDealData{Rating: &rating, PredictionDate: &date},
+	}.Validate()
+
+	var validationErr *rd_station.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.ErrorIs(t, err, rd_station.ErrValidation)
+	assert.Equal(t, []string{"deal.name", "deal.rating", "deal.prediction_date"}, fieldNames(validationErr))
+
+	err = rd_station.UpdateDealRequest{Deal: rd_station.UpdateDealRequestData{
+		Name:           rd_station.Null[string](),
+		Win:            rd_station.Set("won"),
+		Rating:         rd_station.Null[float64](),
+		PredictionDate: rd_station.Set("2020-12-14T15:00:00"),
+	}}.Validate()
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []string{"deal.name", "deal.win"}, fieldNames(validationErr))
+
+	err = rd_station.CreateContactRequest{Contact: rd_station.CreateContactData{
+		Name:   "Maria",
+		Emails: &[]rd_station.EmailData{{Email: "maria@example.com"}, {Email: "Maria <maria@example.com>"}},
+	}}.Validate()
+	assert.EqualError(t, err, `invalid request: contact.emails[1].email: invalid email "Maria <maria@example.com>"`)
+
+	assert.NoError(t, rd_station.UpdateContactRequest{}.Validate())
+	assert.NoError(t, rd_station.ListDealsFilterRequest{Win: "null", Limit: "200"}.Validate())
+
+	err = rd_station.ListDealsFilterRequest{CreatedAtPeriod: "true", StartDate: "2020-12-14", Limit: "500"}.Validate()
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []string{"limit", "created_at_period"}, fieldNames(validationErr))
+
+	assert.NoError(t, rd_station.ListCustomFieldsFilterRequest{For: rd_station.CustomFieldForOrganization}.Validate())
+	err = rd_station.ListCustomFieldsFilterRequest{For: "product"}.Validate()
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []string{"option"}, fieldNames(validationErr))
+}
+
+func TestValidationBeforeSending(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	invalid := rd_station.UpdateDealRequest{Deal: rd_station.UpdateDealRequestData{Rating: rd_station.Set(0.0)}}
+
+	client := rd_station.NewClient(rd_station.WithBaseUrl(server.URL))
+	_, err := client.UpdateDeal(ctx, "1", invalid)
+	assert.ErrorIs(t, err, rd_station.ErrValidation)
+	assert.Equal(t, 0, requests)
+
+	_, err = client.UpdateDeal(ctx, "1", invalid, rd_station.SkipValidation())
+	require.NoError(t, err)
+	assert.Equal(t, 1, requests)
+
+	// the options of a nested call add to the ones of the outer call
+	var metadata rd_station.ResponseMetadata
+	nested := rd_station.WithCallOptions(ctx, []rd_station.CallOption{rd_station.SkipValidation()})
+	_, err = client.UpdateDeal(nested, "1", invalid, rd_station.CaptureMetadata(&metadata))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, metadata.StatusCode)
+	assert.Equal(t, 2, requests)
+
+	client = rd_station.NewClient(rd_station.WithBaseUrl(server.URL), rd_station.WithoutValidation())
+	_, err = client.UpdateDeal(ctx, "1", invalid)
+	require.NoError(t, err)
+	assert.Equal(t, 3, requests)
+}
+
+func TestContactsValidatedBeforePhoneNormalization(t *testing.T) {
+	client := rd_station.NewClient(
+		rd_station.WithBaseUrl("http://localhost:0"),
+		rd_station.WithPhoneNormalizer(rd_station.DefaultPhoneNormalizer()),
+	)
+	ctx := context.Background()
+	phones := []rd_station.PhoneData{{Phone: ""}}
+
+	_, err := client.CreateContact(ctx, rd_station.CreateContactRequest{
+		Contact: rd_station.CreateContactData{Name: "Ana", Phones: &phones},
+	})
+	var validationErr *rd_station.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []string{"contact.phones[0].phone"}, fieldNames(validationErr))
+	assert.NotErrorIs(t, err, rd_station.ErrInvalidPhone)
+
+	_, err = client.UpdateContact(ctx, "1", rd_station.UpdateContactRequest{
+		Contact: rd_station.UpdateContactData{Phones: rd_station.Set([]rd_station.Phone{{Phone: ""}})},
+	})
+	assert.ErrorIs(t, err, rd_station.ErrValidation)
+	assert.NotErrorIs(t, err, rd_station.ErrInvalidPhone)
+}
+
+func fieldNames(err *rd_station.ValidationError) []string {
+	names := make([]string, len(err.Fields))
+	for i, field := range err.Fields {
+		names[i] = field.Field
+	}
+
+	return names
+}